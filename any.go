@@ -0,0 +1,411 @@
+package jsonvalid
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// JSON type.
+type JSONType string
+
+const (
+	JSONNull JSONType = "null"
+	JSONBool JSONType = "boolean"
+	JSONNumber JSONType = "number"
+	JSONString JSONType = "string"
+	JSONArray JSONType = "array"
+	JSONObject JSONType = "object"
+)
+
+func jsonTypeOf(value interface{}) JSONType {
+	switch value.(type) {
+	case nil:
+		return JSONNull
+	case bool:
+		return JSONBool
+	case float64, json.Number:
+		return JSONNumber
+	case string:
+		return JSONString
+	case []interface{}:
+		return JSONArray
+	case map[string]interface{}:
+		return JSONObject
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32:
+		return JSONNumber
+	case reflect.Slice, reflect.Array:
+		return JSONArray
+	}
+
+	return JSONObject
+}
+
+// JSON depth.
+//
+// Returns the nesting depth of arrays and objects in a value, which is zero
+// for scalar values.
+func jsonDepth(value interface{}) int {
+	depth := 0
+
+	switch tv := value.(type) {
+	case []interface{}:
+		for _, elemValue := range tv {
+			if elemDepth := jsonDepth(elemValue); elemDepth > depth {
+				depth = elemDepth
+			}
+		}
+	case map[string]interface{}:
+		for _, propValue := range tv {
+			if propDepth := jsonDepth(propValue); propDepth > depth {
+				depth = propDepth
+			}
+		}
+	default:
+		return 0
+	}
+
+	return depth + 1
+}
+
+// Any validator.
+//
+// Accepts any JSON value, optionally limited by type, encoded size and
+// nesting depth. The value is returned as is.
+type AnyValidator struct {
+	required bool
+	defaultFunc func() interface{}
+	types []JSONType
+	maxSize int
+	maxDepth int
+}
+
+func (v *AnyValidator) clone() *AnyValidator {
+	return &AnyValidator{
+		required: v.required,
+		defaultFunc: v.defaultFunc,
+		types: v.types,
+		maxSize: v.maxSize,
+		maxDepth: v.maxDepth,
+	}
+}
+
+func (v *AnyValidator) Required() *AnyValidator {
+	nv := v.clone()
+	nv.required = true
+	return nv
+}
+
+func (v *AnyValidator) Default(value interface{}) *AnyValidator {
+	return v.DefaultFunc(func() interface{} {
+		return value
+	})
+}
+
+func (v *AnyValidator) DefaultFunc(fn func() interface{}) *AnyValidator {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+func (v *AnyValidator) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
+// Types.
+//
+// Limits the accepted values to the given JSON types.
+func (v *AnyValidator) Types(types ...JSONType) *AnyValidator {
+	nv := v.clone()
+	nv.types = types
+	return nv
+}
+
+// Maximum size.
+//
+// Limits the size in bytes of the JSON encoding of the value.
+func (v *AnyValidator) MaxSize(maxSize int) *AnyValidator {
+	nv := v.clone()
+	nv.maxSize = maxSize
+	return nv
+}
+
+// Maximum depth.
+//
+// Limits the nesting depth of arrays and objects in the value.
+func (v *AnyValidator) MaxDepth(maxDepth int) *AnyValidator {
+	nv := v.clone()
+	nv.maxDepth = maxDepth
+	return nv
+}
+
+func (v *AnyValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	// Test if the value is nil, in which case we can short-circuit to checking
+	// if the value is required.
+	if value == nil {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
+		return nil, nil
+	}
+
+	// Test the type of the value.
+	if v.types != nil {
+		valueType := jsonTypeOf(value)
+		allowed := false
+
+		for _, t := range v.types {
+			if t == valueType {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			typeNames := make([]string, len(v.types))
+			for i, t := range v.types {
+				typeNames[i] = string(t)
+			}
+
+			return nil, ValidationErrorAtPath(path, ValueError{
+				Code: "invalid_type",
+				Message: fmt.Sprintf("Value must be of type %s", strings.Join(typeNames, ", ")),
+				Params: &ErrorParams{
+					"allowed": v.types,
+				},
+			})
+		}
+	}
+
+	// Test the size and depth of the value.
+	if v.maxSize > 0 {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, ValidationErrorAtPath(path, ValueError{
+				Code: "invalid_type",
+				Message: "Value must be a JSON value",
+			})
+		}
+
+		if len(data) > v.maxSize {
+			return nil, ValidationErrorAtPath(path, ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be at most %d byte(s) when encoded", v.maxSize),
+			})
+		}
+	}
+
+	if v.maxDepth > 0 && jsonDepth(value) > v.maxDepth {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid",
+			Message: fmt.Sprintf("Value must be nested at most %d level(s) deep", v.maxDepth),
+		})
+	}
+
+	return value, nil
+}
+
+func Any() *AnyValidator {
+	return &AnyValidator{}
+}
+
+// Raw validator.
+//
+// Accepts any JSON value and returns it as json.RawMessage for later
+// unmarshaling or validation. When the value was parsed from a request, the
+// original bytes are returned. Otherwise, the value is encoded.
+type RawValidator struct {
+	required bool
+	maxSize int
+}
+
+func (v *RawValidator) clone() *RawValidator {
+	return &RawValidator{
+		required: v.required,
+		maxSize: v.maxSize,
+	}
+}
+
+func (v *RawValidator) Required() *RawValidator {
+	nv := v.clone()
+	nv.required = true
+	return nv
+}
+
+// Maximum size.
+//
+// Limits the size in bytes of the raw value.
+func (v *RawValidator) MaxSize(maxSize int) *RawValidator {
+	nv := v.clone()
+	nv.maxSize = maxSize
+	return nv
+}
+
+func (v *RawValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	return v.validateTracking(path, value, nil)
+}
+
+func (v *RawValidator) validateTracking(path Path, value interface{}, tracker *validationTracker) (interface{}, error) {
+	if value == nil {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		return nil, nil
+	}
+
+	// Get the original bytes if possible, or encode the value.
+	var raw json.RawMessage
+
+	if node := tracker.raw(); node != nil {
+		raw = append(json.RawMessage(nil), node.raw...)
+	}
+
+	if raw == nil {
+		if tv, ok := value.(json.RawMessage); ok {
+			raw = tv
+		} else {
+			var err error
+			if raw, err = json.Marshal(value); err != nil {
+				return nil, ValidationErrorAtPath(path, ValueError{
+					Code: "invalid_type",
+					Message: "Value must be a JSON value",
+				})
+			}
+		}
+	}
+
+	if v.maxSize > 0 && len(raw) > v.maxSize {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid",
+			Message: fmt.Sprintf("Value must be at most %d byte(s)", v.maxSize),
+		})
+	}
+
+	return raw, nil
+}
+
+func Raw() *RawValidator {
+	return &RawValidator{}
+}
+
+// Raw JSON node.
+//
+// Holds the original bytes of a value in a JSON document, and the nodes of
+// its properties or elements. As when decoding, the last of several
+// properties with the same name is used.
+type rawJSONNode struct {
+	raw json.RawMessage
+	props map[string]*rawJSONNode
+	elems []*rawJSONNode
+}
+
+func (n *rawJSONNode) prop(name string) *rawJSONNode {
+	if n == nil {
+		return nil
+	}
+
+	return n.props[name]
+}
+
+func (n *rawJSONNode) elem(index int) *rawJSONNode {
+	if n == nil || index >= len(n.elems) {
+		return nil
+	}
+
+	return n.elems[index]
+}
+
+// Raw JSON document.
+//
+// Holds a JSON document which is indexed on first use, so that documents are
+// only walked again when the original JSON of a value is used.
+type rawJSONDocument struct {
+	data []byte
+	once sync.Once
+	node *rawJSONNode
+}
+
+func (d *rawJSONDocument) root() *rawJSONNode {
+	d.once.Do(func() {
+		// The document has already been decoded, so indexing cannot fail. If
+		// it did, the raw validators encode the values instead.
+		d.node, _ = indexRawJSON(d.data)
+	})
+
+	return d.node
+}
+
+// Index raw JSON.
+//
+// Walks a JSON document once, returning the node of its root value.
+func indexRawJSON(document []byte) (*rawJSONNode, error) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	return indexRawJSONValue(decoder, document)
+}
+
+func indexRawJSONValue(decoder *json.Decoder, document []byte) (*rawJSONNode, error) {
+	// Find the start of the value, skipping separators which the decoder has
+	// not consumed yet.
+	start := int(decoder.InputOffset())
+	for start < len(document) && strings.IndexByte(" \t\r\n,:", document[start]) >= 0 {
+		start++
+	}
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &rawJSONNode{}
+
+	switch token {
+	case json.Delim('{'):
+		node.props = make(map[string]*rawJSONNode)
+
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			key, _ := keyToken.(string)
+
+			if node.props[key], err = indexRawJSONValue(decoder, document); err != nil {
+				return nil, err
+			}
+		}
+	case json.Delim('['):
+		for decoder.More() {
+			elem, err := indexRawJSONValue(decoder, document)
+			if err != nil {
+				return nil, err
+			}
+
+			node.elems = append(node.elems, elem)
+		}
+	default:
+		node.raw = document[start:decoder.InputOffset()]
+		return node, nil
+	}
+
+	// Consume the closing delimiter.
+	if _, err = decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	node.raw = document[start:decoder.InputOffset()]
+	return node, nil
+}
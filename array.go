@@ -1,20 +1,41 @@
 package jsonvalid
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 type ArrayValidator struct {
 	required bool
+	defaultFunc func() []interface{}
 	minLen int
+	maxLen int
+	coercer Coercer
 	itemValidator Validator
+	uniqueItems bool
+	uniqueBy string
+	containsValidator Validator
+	minContains int
+	maxContains int
 }
 
 func (v *ArrayValidator) clone() *ArrayValidator {
 	return &ArrayValidator{
 		required: v.required,
+		defaultFunc: v.defaultFunc,
 		minLen: v.minLen,
+		maxLen: v.maxLen,
+		coercer: v.coercer,
 		itemValidator: v.itemValidator,
+		uniqueItems: v.uniqueItems,
+		uniqueBy: v.uniqueBy,
+		containsValidator: v.containsValidator,
+		minContains: v.minContains,
+		maxContains: v.maxContains,
 	}
 }
 
@@ -24,13 +45,69 @@ func (v *ArrayValidator) Required() *ArrayValidator {
 	return nv
 }
 
+func (v *ArrayValidator) Default(value []interface{}) *ArrayValidator {
+	return v.DefaultFunc(func() []interface{} {
+		return value
+	})
+}
+
+func (v *ArrayValidator) DefaultFunc(fn func() []interface{}) *ArrayValidator {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+func (v *ArrayValidator) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
 func (v *ArrayValidator) Of(validator Validator) *ArrayValidator {
 	nv := v.clone()
 	nv.itemValidator = validator
 	return nv
 }
 
+func (v *ArrayValidator) inheritUnknownPolicy(policy *unknownPolicy) Validator {
+	nv := v.clone()
+	nv.itemValidator = inheritUnknownPolicy(v.itemValidator, policy)
+
+	if v.containsValidator != nil {
+		nv.containsValidator = inheritUnknownPolicy(v.containsValidator, policy)
+	}
+
+	return nv
+}
+
+// Coerce.
+//
+// Sets the coercer used instead of the default coercer.
+func (v *ArrayValidator) Coerce(coercer Coercer) *ArrayValidator {
+	nv := v.clone()
+	nv.coercer = coercer
+	return nv
+}
+
+func (v *ArrayValidator) inheritCoercer(coercer Coercer) Validator {
+	nv := v.clone()
+
+	if nv.coercer == nil {
+		nv.coercer = coercer
+	}
+
+	nv.itemValidator = inheritCoercer(v.itemValidator, coercer)
+
+	if v.containsValidator != nil {
+		nv.containsValidator = inheritCoercer(v.containsValidator, coercer)
+	}
+
+	return nv
+}
+
 func (v *ArrayValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	return v.validateTracking(path, value, &validationTracker{})
+}
+
+func (v *ArrayValidator) validateTracking(path Path, value interface{}, tracker *validationTracker) (interface{}, error) {
 	// Test if the value is nil, in which case we can short-circuit to checking
 	// if the value is required.
 	if value == nil {
@@ -38,11 +115,15 @@ func (v *ArrayValidator) Validate(path Path, value interface{}) (interface{}, er
 			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
 		}
 
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
 		return []interface{}{}, nil
 	}
 
 	// Test if the value is an array.
-	arrValue, ok := value.([]interface{})
+	arrValue, ok := coerce(v.coercer, CoerceToArray, value).([]interface{})
 	if !ok {
 		return nil, ValidationErrorAtPath(path, ValueError{
 			Code: "invalid_type",
@@ -54,12 +135,20 @@ func (v *ArrayValidator) Validate(path Path, value interface{}) (interface{}, er
 		return nil, ValidationErrorAtPath(path, ValueErrorRequired)
 	}
 
+	// Reject oversized arrays before validating any elements.
+	if v.maxLen >= 0 && len(arrValue) > v.maxLen {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid",
+			Message: fmt.Sprintf("Value must be an array of at most %d element(s)", v.maxLen),
+		})
+	}
+
 	// Construct a result.
 	var err *ValidationError
 	result := make([]interface{}, 0, len(arrValue))
 
 	for i, elemValue := range arrValue {
-		resultValue, resultErr := v.itemValidator.Validate(path.Elem(i), elemValue)
+		resultValue, resultErr := validateTracking(v.itemValidator, path.Elem(i), elemValue, tracker.Elem(i))
 
 		if resultErr != nil {
 			if resultValidationErr, ok := resultErr.(*ValidationError); ok {
@@ -83,6 +172,81 @@ func (v *ArrayValidator) Validate(path Path, value interface{}) (interface{}, er
 		})
 	}
 
+	if err != nil {
+		return result, err
+	}
+
+	// Validate uniqueness of the validated elements.
+	if v.uniqueItems {
+		seen := make(map[string]struct{}, len(result))
+
+		for i, elem := range result {
+			key := uniqueKey(elem)
+
+			if _, ok := seen[key]; ok {
+				err = concatValidationError(err, ValidationErrorAtPath(path.Elem(i), ValueError{
+					Code: "duplicate",
+					Message: "Value must not be a duplicate of another element",
+				}))
+			} else {
+				seen[key] = struct{}{}
+			}
+		}
+	}
+
+	if v.uniqueBy != "" {
+		seen := make(map[string]struct{}, len(result))
+
+		for i, elem := range result {
+			elemKey, ok := arrayElemKey(elem, v.uniqueBy)
+			if !ok {
+				continue
+			}
+
+			key := uniqueKey(elemKey)
+
+			if _, ok := seen[key]; ok {
+				err = concatValidationError(err, ValidationErrorAtPath(tracker.propPath(path.Elem(i), v.uniqueBy), ValueError{
+					Code: "duplicate",
+					Message: "Value must not be a duplicate of another element",
+				}))
+			} else {
+				seen[key] = struct{}{}
+			}
+		}
+	}
+
+	// Validate the elements contained.
+	if v.containsValidator != nil {
+		contained := 0
+
+		for i, elemValue := range arrValue {
+			// Defaults of elements which are merely tested are not tracked.
+			containsTracker := tracker.Elem(i)
+			if containsTracker != nil {
+				containsTracker.defaults = nil
+			}
+
+			if _, containsErr := validateTracking(v.containsValidator, path.Elem(i), elemValue, containsTracker); containsErr == nil {
+				contained++
+			} else if _, ok := containsErr.(*ValidationError); !ok {
+				return nil, containsErr
+			}
+		}
+
+		if contained < v.minContains {
+			err = concatValidationError(err, ValidationErrorAtPath(path, ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must contain at least %d matching element(s)", v.minContains),
+			}))
+		} else if v.maxContains >= 0 && contained > v.maxContains {
+			err = concatValidationError(err, ValidationErrorAtPath(path, ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must contain at most %d matching element(s)", v.maxContains),
+			}))
+		}
+	}
+
 	if err == nil {
 		return result, nil
 	} else {
@@ -96,10 +260,171 @@ func (v *ArrayValidator) MinLen(minLen int) *ArrayValidator {
 	return nv
 }
 
+func (v *ArrayValidator) MaxLen(maxLen int) *ArrayValidator {
+	nv := v.clone()
+	nv.maxLen = maxLen
+	return nv
+}
+
+// Unique items.
+//
+// Requires all validated elements to be distinct. Elements are compared by
+// their JSON representation, with numbers compared by value regardless of
+// their type.
+func (v *ArrayValidator) UniqueItems() *ArrayValidator {
+	nv := v.clone()
+	nv.uniqueItems = true
+	return nv
+}
+
+// Unique by property.
+//
+// Requires the given property of all validated object elements to be
+// distinct, compared as with UniqueItems. Elements unmarshaled to structs are compared by the field with the
+// matching JSON name.
+func (v *ArrayValidator) UniqueBy(property string) *ArrayValidator {
+	nv := v.clone()
+	nv.uniqueBy = property
+	return nv
+}
+
+// Contains.
+//
+// Requires at least one element, or the number set with MinContains, to be
+// valid according to the validator.
+func (v *ArrayValidator) Contains(validator Validator) *ArrayValidator {
+	nv := v.clone()
+	nv.containsValidator = validator
+	return nv
+}
+
+func (v *ArrayValidator) MinContains(minContains int) *ArrayValidator {
+	nv := v.clone()
+	nv.minContains = minContains
+	return nv
+}
+
+func (v *ArrayValidator) MaxContains(maxContains int) *ArrayValidator {
+	nv := v.clone()
+	nv.maxContains = maxContains
+	return nv
+}
+
+// Key of array element.
+//
+// Resolves the value of a property of an object element, either as a map or
+// unmarshaled to a struct.
+func arrayElemKey(elem interface{}, property string) (interface{}, bool) {
+	if obj, ok := elem.(map[string]interface{}); ok {
+		key, ok := obj[property]
+		return key, ok
+	}
+
+	value := reflect.ValueOf(elem)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, false
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Name
+		if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" {
+			name = tag
+		}
+
+		if name == property {
+			return value.Field(i).Interface(), true
+		}
+	}
+
+	return nil, false
+}
+
 func Array() *ArrayValidator {
-	return &ArrayValidator{}
+	return &ArrayValidator{
+		maxLen: -1,
+		minContains: 1,
+		maxContains: -1,
+	}
 }
 
 func ArrayOf(of Validator) *ArrayValidator {
 	return Array().Of(of)
 }
+
+// Unique key of value.
+//
+// Encodes a value canonically, so that values which are equal as JSON have
+// the same key. Numbers are encoded by value, and object properties are
+// sorted by name.
+func uniqueKey(value interface{}) string {
+	var b strings.Builder
+	writeUniqueKey(&b, value)
+	return b.String()
+}
+
+func writeUniqueKey(b *strings.Builder, value interface{}) {
+	if num, ok := jsonNumber(value); ok {
+		b.WriteString(num.RatString())
+		return
+	}
+
+	switch tv := value.(type) {
+	case nil:
+		b.WriteString("null")
+	case bool:
+		b.WriteString(strconv.FormatBool(tv))
+	case string:
+		b.WriteString(strconv.Quote(tv))
+	case []interface{}:
+		b.WriteByte('[')
+		for i, elem := range tv {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+
+			writeUniqueKey(b, elem)
+		}
+		b.WriteByte(']')
+	case map[string]interface{}:
+		names := make([]string, 0, len(tv))
+		for name := range tv {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		b.WriteByte('{')
+		for i, name := range names {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+
+			b.WriteString(strconv.Quote(name))
+			b.WriteByte(':')
+			writeUniqueKey(b, tv[name])
+		}
+		b.WriteByte('}')
+	default:
+		// Encode other values, such as unmarshaled structs, as JSON prefixed by
+		// their type.
+		if data, err := json.Marshal(tv); err == nil {
+			fmt.Fprintf(b, "%T:%s", tv, data)
+		} else {
+			fmt.Fprintf(b, "%T:%#v", tv, tv)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package jsonvalid
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -90,3 +91,139 @@ func TestArray(t *testing.T) {
 		[]interface{}{nil, 1, 5},
 	)
 }
+
+func TestArrayConstraints(t *testing.T) {
+	// Test maximum length.
+	AssertArrayValidationResult(
+		t,
+		"array of two values",
+		"max length array validator",
+		ArrayOf(String()).MaxLen(2),
+		[]interface{}{"a", "b"},
+		[]interface{}{"a", "b"},
+	)
+	AssertArrayValidationFails(
+		t,
+		"array of three values",
+		"max length array validator",
+		ArrayOf(String()).MaxLen(2),
+		[]interface{}{"a", "b", "c"},
+	)
+
+	// Test unique items.
+	AssertArrayValidationFails(
+		t,
+		"array with duplicate values",
+		"unique items array validator",
+		ArrayOf(String().Strip()).UniqueItems(),
+		[]interface{}{"a", " a "},
+	)
+
+	// Test unique by property.
+	uniqueByValidator := ArrayOf(Object(Prop("id", String()))).UniqueBy("id")
+
+	AssertArrayValidationResult(
+		t,
+		"array of objects with distinct keys",
+		"unique by array validator",
+		uniqueByValidator,
+		[]interface{}{map[string]interface{}{"id": "a"}, map[string]interface{}{"id": "b"}},
+		[]interface{}{nil, nil},
+	)
+
+	_, err := uniqueByValidator.Validate("items", []interface{}{
+		map[string]interface{}{"id": "a"},
+		map[string]interface{}{"id": "a"},
+	})
+	if validationErr, ok := err.(*ValidationError); !ok || len(validationErr.Fields) != 1 || validationErr.Fields[0].Path != "items[1].id" {
+		t.Errorf("expected duplicate error at items[1].id but got: %v", err)
+	}
+
+	// Test contains.
+	containsValidator := ArrayOf(String()).Contains(String().OneOf("admin")).MaxContains(1)
+
+	AssertArrayValidationResult(
+		t,
+		"array containing one match",
+		"contains array validator",
+		containsValidator,
+		[]interface{}{"user", "admin"},
+		[]interface{}{"user", "admin"},
+	)
+	AssertArrayValidationFails(
+		t,
+		"array containing no match",
+		"contains array validator",
+		containsValidator,
+		[]interface{}{"user"},
+	)
+	AssertArrayValidationFails(
+		t,
+		"array containing two matches",
+		"contains array validator",
+		containsValidator,
+		[]interface{}{"admin", "admin"},
+	)
+}
+
+func TestArrayUniqueItems(t *testing.T) {
+	// Test that numbers are compared by value and objects regardless of the
+	// order of their properties.
+	for _, c := range []struct {
+		desc string
+		value []interface{}
+	}{
+		{"float64 and json.Number", []interface{}{1.0, json.Number("1.0")}},
+		{"json.Number with exponent", []interface{}{json.Number("100"), json.Number("1e2")}},
+		{"nested objects", []interface{}{
+			map[string]interface{}{"a": 1.0, "b": []interface{}{"x"}},
+			map[string]interface{}{"b": []interface{}{"x"}, "a": json.Number("1")},
+		}},
+	} {
+		_, err := ArrayOf(Any()).UniqueItems().Validate("", c.value)
+		AssertFieldError(t, err, "[1]", "duplicate")
+	}
+
+	if _, err := ArrayOf(Any()).UniqueItems().Validate("", []interface{}{1.0, "1", nil, "null", []interface{}{1.0}, map[string]interface{}{}}); err != nil {
+		t.Errorf("unexpected error validating array of distinct values: %v", err)
+	}
+
+	// Test that large arrays are validated quickly, reporting every duplicate.
+	value := make([]interface{}, 20000)
+	for i := range value {
+		value[i] = float64(i % 10000)
+	}
+
+	_, err := ArrayOf(Float64()).UniqueItems().Validate("", value)
+	if validationErr, ok := err.(*ValidationError); !ok || len(validationErr.Fields) != 10000 || validationErr.Fields[0].Path != "[10000]" {
+		t.Errorf("expected 10000 duplicate errors from validating large array but got: %v", err)
+	}
+
+	// Test unique by property with numbers of different types.
+	_, err = ArrayOf(Object(Prop("id", Any()))).UniqueBy("id").Validate("", []interface{}{
+		map[string]interface{}{"id": 2.0},
+		map[string]interface{}{"id": 3.0},
+		map[string]interface{}{"id": json.Number("2")},
+	})
+	AssertFieldError(t, err, "[2].id", "duplicate")
+}
+
+func TestArrayContainsRecursive(t *testing.T) {
+	var nested Validator
+	nested = Lazy(func() Validator {
+		return ArrayOf(Any()).Contains(nested)
+	}).MaxDepth(3)
+
+	value := []interface{}{}
+	for i := 0; i < 2000; i++ {
+		value = []interface{}{value}
+	}
+
+	if _, err := nested.Validate("", value); err == nil {
+		t.Errorf("expected error from validating deeply nested arrays with recursive contains validator")
+	}
+
+	if _, err := nested.Validate("", []interface{}{[]interface{}{}}); err == nil {
+		t.Errorf("expected error from validating arrays without matching elements")
+	}
+}
@@ -0,0 +1,167 @@
+package jsonvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+type BigIntValueValidator func(value *big.Int) (*big.Int, *ValueError)
+
+// Big integer validator.
+//
+// Validates integers of arbitrary size, returning a *big.Int. To avoid losing
+// precision, values should be decoded as json.Number.
+type BigIntValidator struct {
+	required bool
+	defaultFunc func() *big.Int
+	maxDigits int
+	valueValidators []BigIntValueValidator
+}
+
+func (v *BigIntValidator) clone() *BigIntValidator {
+	return &BigIntValidator{
+		required: v.required,
+		defaultFunc: v.defaultFunc,
+		maxDigits: v.maxDigits,
+		valueValidators: v.valueValidators,
+	}
+}
+
+func (v *BigIntValidator) Required() *BigIntValidator {
+	nv := v.clone()
+	nv.required = true
+	return nv
+}
+
+func (v *BigIntValidator) Default(value *big.Int) *BigIntValidator {
+	return v.DefaultFunc(func() *big.Int {
+		return value
+	})
+}
+
+func (v *BigIntValidator) DefaultFunc(fn func() *big.Int) *BigIntValidator {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+func (v *BigIntValidator) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
+// Maximum number of digits.
+//
+// The limit is checked before the value is parsed.
+func (v *BigIntValidator) MaxDigits(maxDigits int) *BigIntValidator {
+	nv := v.clone()
+	nv.maxDigits = maxDigits
+	return nv
+}
+
+func (v *BigIntValidator) ValidateValue(bivv BigIntValueValidator) *BigIntValidator {
+	nv := v.clone()
+	nv.valueValidators = make([]BigIntValueValidator, 0, len(v.valueValidators) + 1)
+	nv.valueValidators = append(nv.valueValidators, v.valueValidators...)
+	nv.valueValidators = append(nv.valueValidators, bivv)
+	return nv
+}
+
+func (v *BigIntValidator) Min(minValue *big.Int) *BigIntValidator {
+	return v.ValidateValue(func(value *big.Int) (*big.Int, *ValueError) {
+		if value.Cmp(minValue) < 0 {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be at least %s", minValue),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func (v *BigIntValidator) Max(maxValue *big.Int) *BigIntValidator {
+	return v.ValidateValue(func(value *big.Int) (*big.Int, *ValueError) {
+		if value.Cmp(maxValue) > 0 {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be at most %s", maxValue),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func (v *BigIntValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	// Test if the value is nil, in which case we can short-circuit to checking
+	// if the value is required.
+	if value == nil {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
+		return nil, nil
+	}
+
+	// Resolve the textual representation of the value. Floating point numbers
+	// are accepted as long as they have not lost precision.
+	var text string
+
+	switch tv := value.(type) {
+	case json.Number:
+		text = string(tv)
+	case string:
+		text = tv
+	default:
+		int64Value, valueErr := parseInteger[int64](value)
+		if valueErr != nil {
+			return nil, ValidationErrorAtPath(path, *valueErr)
+		}
+
+		text = fmt.Sprint(int64Value)
+	}
+
+	// Test the number of digits before parsing plain integers, so that overly
+	// long numbers are never parsed.
+	if v.maxDigits > 0 && !strings.ContainsAny(text, ".eE") && len(strings.TrimLeft(text, "+-0")) > v.maxDigits {
+		return nil, ValidationErrorAtPath(path, bigIntMaxDigitsError(v.maxDigits))
+	}
+
+	ratValue, ok := parseExactNumber(text)
+	if !ok || !ratValue.IsInt() {
+		return nil, ValidationErrorAtPath(path, valueErrorNotInteger)
+	}
+
+	intValue := new(big.Int).Set(ratValue.Num())
+
+	if v.maxDigits > 0 && len(new(big.Int).Abs(intValue).String()) > v.maxDigits {
+		return nil, ValidationErrorAtPath(path, bigIntMaxDigitsError(v.maxDigits))
+	}
+
+	// Validate the value.
+	for _, valueValidator := range v.valueValidators {
+		var err *ValueError
+		if intValue, err = valueValidator(intValue); err != nil {
+			return intValue, ValidationErrorAtPath(path, *err)
+		}
+	}
+
+	return intValue, nil
+}
+
+func bigIntMaxDigitsError(maxDigits int) ValueError {
+	return ValueError{
+		Code: "invalid",
+		Message: fmt.Sprintf("Value must have at most %d digit(s)", maxDigits),
+	}
+}
+
+func BigInt() *BigIntValidator {
+	return &BigIntValidator{}
+}
@@ -0,0 +1,99 @@
+package jsonvalid
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBigInt(t *testing.T) {
+	for _, c := range []struct {
+		value interface{}
+		expected string
+	}{
+		{json.Number("123456789012345678901234567890"), "123456789012345678901234567890"},
+		{json.Number("-1.5e3"), "-1500"},
+		{"+42", "42"},
+		{12.0, "12"},
+		{7, "7"},
+	} {
+		result, err := BigInt().Validate("", c.value)
+		if err != nil {
+			t.Errorf("unexpected error validating %#v with big integer validator: %v", c.value, err)
+		} else if result.(*big.Int).String() != c.expected {
+			t.Errorf("expected result of validating %#v with big integer validator to be %s but it is: %v", c.value, c.expected, result)
+		}
+	}
+
+	for _, value := range []interface{}{json.Number("1.5"), json.Number("1e10000"), "0x10", 1.5, 1e20, true} {
+		if _, err := BigInt().Validate("", value); err == nil {
+			t.Errorf("expected error from validating %#v with big integer validator", value)
+		}
+	}
+
+	// Test bounds.
+	validator := BigInt().Min(big.NewInt(-10)).Max(big.NewInt(10))
+
+	for _, value := range []json.Number{"-11", "11"} {
+		if _, err := validator.Validate("", value); err == nil {
+			t.Errorf("expected error from validating %s with bounded big integer validator", value)
+		}
+	}
+}
+
+func TestBigIntMaxDigits(t *testing.T) {
+	validator := BigInt().MaxDigits(3)
+
+	for _, value := range []json.Number{"999", "-999", "000999", "9.99e2"} {
+		if _, err := validator.Validate("", value); err != nil {
+			t.Errorf("unexpected error validating %s with maximum digits: %v", value, err)
+		}
+	}
+
+	for _, value := range []json.Number{"1000", "-1000", "1e3", "1000.0"} {
+		if _, err := validator.Validate("", value); err == nil {
+			t.Errorf("expected error from validating %s with maximum digits", value)
+		}
+	}
+
+	// Test that overly long numbers are rejected by their number of digits
+	// rather than parsed.
+	_, err := validator.Validate("", json.Number(strings.Repeat("9", 1000000)))
+	if validationErr, ok := err.(*ValidationError); !ok || len(validationErr.Fields) != 1 || validationErr.Fields[0].Message != "Value must have at most 3 digit(s)" {
+		t.Errorf("expected maximum digits error from validating long number but got: %v", err)
+	}
+}
+
+func TestParseAndValidateHttpRequestNumbers(t *testing.T) {
+	validator := Object(Prop("id", BigInt()), Prop("count", Int64()))
+
+	// Test that numbers are decoded without losing precision.
+	req, _ := http.NewRequest("POST", "/", strings.NewReader(`{"id": 123456789012345678901234567890, "count": 9007199254740993}`))
+
+	result, err := validator.ParseAndValidateHttpRequest(req)
+	if err != nil {
+		t.Fatalf("unexpected error validating request with large numbers: %v", err)
+	}
+
+	obj := result.(map[string]interface{})
+	if obj["id"].(*big.Int).String() != "123456789012345678901234567890" || obj["count"] != int64(9007199254740993) {
+		t.Errorf("unexpected result from validating request with large numbers: %v", obj)
+	}
+
+	// Test that trailing data is rejected.
+	for _, body := range []string{`{"id": 1} {"id": 2}`, `{"id": 1} x`, `{"id": 1}]`} {
+		req, _ = http.NewRequest("POST", "/", strings.NewReader(body))
+
+		if _, err = validator.ParseAndValidateHttpRequest(req); err != ErrParseError {
+			t.Errorf("expected parse error from validating request body %s but got: %v", body, err)
+		}
+	}
+
+	req, _ = http.NewRequest("POST", "/", strings.NewReader("{\"id\": 1}\n\t "))
+
+	if _, err = validator.ParseAndValidateHttpRequest(req); err != nil {
+		t.Errorf("unexpected error validating request body with trailing whitespace: %v", err)
+	}
+}
@@ -1,14 +1,16 @@
 package jsonvalid
 
 type BoolValidator struct {
-	defaultValue bool
 	required bool
+	defaultFunc func() bool
+	coercer Coercer
 }
 
 func (v *BoolValidator) clone() *BoolValidator {
 	return &BoolValidator{
-		defaultValue: v.defaultValue,
 		required: v.required,
+		defaultFunc: v.defaultFunc,
+		coercer: v.coercer,
 	}
 }
 
@@ -19,11 +21,38 @@ func (v *BoolValidator) Required() *BoolValidator {
 }
 
 func (v *BoolValidator) Default(value bool) *BoolValidator {
+	return v.DefaultFunc(func() bool {
+		return value
+	})
+}
+
+func (v *BoolValidator) DefaultFunc(fn func() bool) *BoolValidator {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+func (v *BoolValidator) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
+// Coerce.
+//
+// Sets the coercer used instead of the default coercer.
+func (v *BoolValidator) Coerce(coercer Coercer) *BoolValidator {
 	nv := v.clone()
-	nv.defaultValue = value
+	nv.coercer = coercer
 	return nv
 }
 
+func (v *BoolValidator) inheritCoercer(coercer Coercer) Validator {
+	if v.coercer != nil {
+		return v
+	}
+
+	return v.Coerce(coercer)
+}
+
 func (v *BoolValidator) Validate(path Path, value interface{}) (interface{}, error) {
 	// Test if the value is nil, in which case we can short-circuit to checking
 	// if the value is required.
@@ -32,11 +61,15 @@ func (v *BoolValidator) Validate(path Path, value interface{}) (interface{}, err
 			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
 		}
 
-		return v.defaultValue, nil
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
+		return false, nil
 	}
 
 	// Test if the value is a boolean.
-	boolValue, ok := value.(bool)
+	boolValue, ok := coerce(v.coercer, CoerceToBool, value).(bool)
 	if !ok {
 		return nil, ValidationErrorAtPath(path, ValueError{
 			Code: "invalid_type",
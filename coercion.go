@@ -0,0 +1,114 @@
+package jsonvalid
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Coercion target.
+//
+// The JSON type a validator expects a value to be coerced to.
+type CoercionTarget int
+
+const (
+	CoerceToNumber CoercionTarget = iota
+	CoerceToBool
+	CoerceToArray
+)
+
+// Coercer.
+//
+// Converts a value to the JSON type expected by a validator before it is
+// validated. Numbers must be coerced to float64 or json.Number. Values which
+// cannot be coerced should be returned unchanged.
+type Coercer func(target CoercionTarget, value interface{}) interface{}
+
+// Strict coercion.
+//
+// Only accepts values of the expected JSON type.
+func StrictCoercion(target CoercionTarget, value interface{}) interface{} {
+	return value
+}
+
+// Numeric string coercion.
+//
+// Accepts numeric strings for numbers. This is the default coercion.
+func NumericStringCoercion(target CoercionTarget, value interface{}) interface{} {
+	if str, ok := value.(string); ok && target == CoerceToNumber && numericStringRegexp.MatchString(str) {
+		return json.Number(strings.TrimPrefix(str, "+"))
+	}
+
+	return value
+}
+
+// Lenient coercion.
+//
+// Accepts numeric strings for numbers, and the strings "true", "yes", "on" and
+// "1" as well as "false", "no", "off" and "0" for booleans. Surrounding
+// whitespace and case are ignored. Single values are accepted as arrays of
+// one element.
+func LenientCoercion(target CoercionTarget, value interface{}) interface{} {
+	if target == CoerceToArray {
+		if _, ok := value.([]interface{}); !ok {
+			return []interface{}{value}
+		}
+
+		return value
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return value
+	}
+
+	str = strings.TrimSpace(str)
+
+	switch target {
+	case CoerceToNumber:
+		return NumericStringCoercion(target, str)
+	case CoerceToBool:
+		switch strings.ToLower(str) {
+		case "true", "yes", "on", "1":
+			return true
+		case "false", "no", "off", "0":
+			return false
+		}
+	}
+
+	return value
+}
+
+var defaultCoercer Coercer = NumericStringCoercion
+
+// Set default coercer.
+//
+// Sets the coercer used by validators for which no coercer has been set. Must
+// not be called concurrently with validation.
+func SetDefaultCoercer(coercer Coercer) {
+	defaultCoercer = coercer
+}
+
+func coerce(coercer Coercer, target CoercionTarget, value interface{}) interface{} {
+	if coercer == nil {
+		coercer = defaultCoercer
+	}
+
+	return coercer(target, value)
+}
+
+// Coercer inheritor.
+//
+// Implemented by validators which coerce values or contain other validators,
+// so that a coercer can be applied to a whole validator tree without
+// overriding coercers set on individual validators.
+type coercerInheritor interface {
+	inheritCoercer(coercer Coercer) Validator
+}
+
+func inheritCoercer(validator Validator, coercer Coercer) Validator {
+	if inheritor, ok := validator.(coercerInheritor); ok {
+		return inheritor.inheritCoercer(coercer)
+	}
+
+	return validator
+}
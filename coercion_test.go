@@ -0,0 +1,121 @@
+package jsonvalid
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestCoercers(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		coercer Coercer
+		target CoercionTarget
+		value interface{}
+		expected interface{}
+	}{
+		{"numeric string with strict coercion", StrictCoercion, CoerceToNumber, "12", "12"},
+		{"bool string with strict coercion", StrictCoercion, CoerceToBool, "true", "true"},
+		{"numeric string with numeric string coercion", NumericStringCoercion, CoerceToNumber, "+12", json.Number("12")},
+		{"padded numeric string with numeric string coercion", NumericStringCoercion, CoerceToNumber, " 12", " 12"},
+		{"hexadecimal string with numeric string coercion", NumericStringCoercion, CoerceToNumber, "0x10", "0x10"},
+		{"bool string with numeric string coercion", NumericStringCoercion, CoerceToBool, "true", "true"},
+		{"padded numeric string with lenient coercion", LenientCoercion, CoerceToNumber, " 1.5e2 ", json.Number("1.5e2")},
+		{"bool string with lenient coercion", LenientCoercion, CoerceToBool, " Yes", true},
+		{"false string with lenient coercion", LenientCoercion, CoerceToBool, "off", false},
+		{"unknown bool string with lenient coercion", LenientCoercion, CoerceToBool, "maybe", "maybe"},
+		{"number with lenient coercion", LenientCoercion, CoerceToNumber, 12.0, 12.0},
+	} {
+		if result := c.coercer(c.target, c.value); result != c.expected {
+			t.Errorf("expected %s to result in %#v but got: %#v", c.desc, c.expected, result)
+		}
+	}
+
+	// Test that single values are coerced to arrays.
+	if result, ok := LenientCoercion(CoerceToArray, "a").([]interface{}); !ok || len(result) != 1 || result[0] != "a" {
+		t.Errorf("unexpected result from coercing single value to array with lenient coercion: %#v", result)
+	}
+
+	if result := NumericStringCoercion(CoerceToArray, "a"); result != "a" {
+		t.Errorf("unexpected result from coercing single value to array with numeric string coercion: %#v", result)
+	}
+}
+
+func TestCoerceOverridesDefault(t *testing.T) {
+	defer SetDefaultCoercer(NumericStringCoercion)
+
+	// Test the numeric string default.
+	if _, err := Int().Validate("", "12"); err != nil {
+		t.Errorf("unexpected error validating numeric string with default coercion: %v", err)
+	}
+
+	if _, err := Int().Coerce(StrictCoercion).Validate("", "12"); err == nil {
+		t.Errorf("expected error from validating numeric string with strict coercion")
+	}
+
+	// Test that validators with a coercer are not affected by the default.
+	SetDefaultCoercer(StrictCoercion)
+
+	if _, err := Int().Validate("", "12"); err == nil {
+		t.Errorf("expected error from validating numeric string with strict default coercion")
+	}
+
+	if result, err := Int().Coerce(LenientCoercion).Validate("", " 12 "); err != nil || result != 12 {
+		t.Errorf("unexpected result from validating numeric string with lenient coercion: %v, %v", result, err)
+	}
+
+	if result, err := Bool().Coerce(LenientCoercion).Validate("", "on"); err != nil || result != true {
+		t.Errorf("unexpected result from validating bool string with lenient coercion: %v, %v", result, err)
+	}
+
+	// Test that inherited coercers do not override coercers set on validators.
+	validator := inheritCoercer(Object(
+		Prop("lenient", Int()),
+		Prop("strict", Int().Coerce(StrictCoercion)),
+	), LenientCoercion)
+
+	if _, err := validator.Validate("", map[string]interface{}{"lenient": " 1 "}); err != nil {
+		t.Errorf("unexpected error validating numeric string with inherited coercion: %v", err)
+	}
+
+	_, err := validator.Validate("", map[string]interface{}{"strict": "1"})
+	AssertFieldError(t, err, "strict", "invalid_type")
+}
+
+func TestCoercionFormValues(t *testing.T) {
+	type priority int
+
+	validator := Object(
+		Prop("priority", Enum[priority]().IntValue(1, 1).IntValue(2, 2).Value("high", 3)),
+		Prop("at", Time().Epoch()),
+		Prop("amount", Decimal()),
+	)
+
+	values, _ := url.ParseQuery("priority=2&at=1700000000&amount=%201.50%20")
+
+	result, err := validator.ParseAndValidateValues(values)
+	if err != nil {
+		t.Fatalf("unexpected error validating form values: %v", err)
+	}
+
+	obj := result.(map[string]interface{})
+	if obj["priority"] != priority(2) || !obj["at"].(time.Time).Equal(time.Unix(1700000000, 0)) || obj["amount"] != json.Number("1.50") {
+		t.Errorf("unexpected result from validating form values: %v", obj)
+	}
+
+	values, _ = url.ParseQuery("priority=high&at=2023-11-14T22:13:20Z")
+
+	if result, err = validator.ParseAndValidateValues(values); err != nil {
+		t.Errorf("unexpected error validating form values: %v", err)
+	} else if obj = result.(map[string]interface{}); obj["priority"] != priority(3) || !obj["at"].(time.Time).Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("unexpected result from validating form values: %v", obj)
+	}
+
+	// Test that coercers set on validators are not overridden.
+	strict := Object(Prop("priority", Enum[priority]().IntValue(1, 1).Coerce(StrictCoercion)))
+	values, _ = url.ParseQuery("priority=1")
+
+	_, err = strict.ParseAndValidateValues(values)
+	AssertFieldError(t, err, "priority", "invalid")
+}
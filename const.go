@@ -0,0 +1,171 @@
+package jsonvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// Const validator.
+//
+// Validates that a value equals a constant JSON value, compared deeply with
+// numbers compared by value. The result is the constant as given.
+type ConstValidator struct {
+	required bool
+	value interface{}
+	jsonValue interface{}
+}
+
+func (v *ConstValidator) clone() *ConstValidator {
+	return &ConstValidator{
+		required: v.required,
+		value: v.value,
+		jsonValue: v.jsonValue,
+	}
+}
+
+func (v *ConstValidator) Required() *ConstValidator {
+	nv := v.clone()
+	nv.required = true
+	return nv
+}
+
+func (v *ConstValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	// Test if the value is nil, in which case we can short-circuit to checking
+	// if the value is required.
+	if value == nil && v.jsonValue != nil {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		return nil, nil
+	}
+
+	if !jsonEqual(value, v.jsonValue) {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid",
+			Message: fmt.Sprintf("Value must be %s", jsonString(v.jsonValue)),
+			Params: &ErrorParams{
+				"expected": v.jsonValue,
+			},
+		})
+	}
+
+	return v.value, nil
+}
+
+// Const.
+//
+// The value must be representable as JSON.
+func Const(value interface{}) *ConstValidator {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(fmt.Sprintf("jsonvalid: invalid constant: %v", err))
+	}
+
+	var jsonValue interface{}
+	if err = decodeJSON(data, &jsonValue); err != nil {
+		panic(fmt.Sprintf("jsonvalid: invalid constant: %v", err))
+	}
+
+	return &ConstValidator{
+		value: value,
+		jsonValue: jsonValue,
+	}
+}
+
+// Null validator.
+//
+// Only accepts null or missing values.
+type NullValidator struct{}
+
+func (v *NullValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	if value != nil {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid_type",
+			Message: "Value must be null",
+		})
+	}
+
+	return nil, nil
+}
+
+func Null() *NullValidator {
+	return &NullValidator{}
+}
+
+// JSON equality.
+//
+// Deeply compares generic JSON values, comparing numbers by value regardless
+// of whether they are float64, json.Number or Go integers.
+func jsonEqual(a, b interface{}) bool {
+	if aNum, ok := jsonNumber(a); ok {
+		bNum, ok := jsonNumber(b)
+		return ok && aNum.Cmp(bNum) == 0
+	}
+
+	switch ta := a.(type) {
+	case nil:
+		return b == nil
+	case []interface{}:
+		tb, ok := b.([]interface{})
+		if !ok || len(ta) != len(tb) {
+			return false
+		}
+
+		for i := range ta {
+			if !jsonEqual(ta[i], tb[i]) {
+				return false
+			}
+		}
+
+		return true
+	case map[string]interface{}:
+		tb, ok := b.(map[string]interface{})
+		if !ok || len(ta) != len(tb) {
+			return false
+		}
+
+		for key, value := range ta {
+			other, ok := tb[key]
+			if !ok || !jsonEqual(value, other) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+func jsonNumber(value interface{}) (*big.Rat, bool) {
+	switch tv := value.(type) {
+	case float64:
+		return new(big.Rat).SetFloat64(tv), !math.IsNaN(tv) && !math.IsInf(tv, 0)
+	case json.Number:
+		return parseExactNumber(string(tv))
+	}
+
+	refValue := reflect.ValueOf(value)
+
+	switch refValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(refValue.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Rat).SetUint64(refValue.Uint()), true
+	}
+
+	return nil, false
+}
+
+func jsonString(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
@@ -0,0 +1,142 @@
+package jsonvalid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type constTestVersion struct {
+	Major int `json:"major"`
+	Tags []string `json:"tags"`
+}
+
+func TestConst(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		constant interface{}
+		accepted []interface{}
+		rejected []interface{}
+	}{
+		{
+			"number",
+			2,
+			[]interface{}{2.0, json.Number("2"), json.Number("2.0"), json.Number("0.2e1"), 2, int64(2), uint8(2)},
+			[]interface{}{2.5, json.Number("3"), "2", "2.0", true},
+		},
+		{
+			"string",
+			"v1",
+			[]interface{}{"v1"},
+			[]interface{}{"V1", 1.0},
+		},
+		{
+			"nested array",
+			[]interface{}{1, []interface{}{"a", true}},
+			[]interface{}{[]interface{}{1.0, []interface{}{"a", true}}, []interface{}{json.Number("1"), []interface{}{"a", true}}},
+			[]interface{}{[]interface{}{1.0, []interface{}{"a"}}, []interface{}{1.0, []interface{}{"a", true}, nil}, []interface{}{[]interface{}{"a", true}, 1.0}},
+		},
+		{
+			"nested object",
+			constTestVersion{2, []string{"lts"}},
+			[]interface{}{map[string]interface{}{"tags": []interface{}{"lts"}, "major": json.Number("2.0")}},
+			[]interface{}{map[string]interface{}{"major": 2.0}, map[string]interface{}{"major": 2.0, "tags": []interface{}{"lts"}, "minor": 0.0}, map[string]interface{}{"major": 2.0, "tags": []interface{}{"beta"}}},
+		},
+	} {
+		validator := Const(c.constant)
+
+		for _, value := range c.accepted {
+			if result, err := validator.Validate("", value); err != nil {
+				t.Errorf("unexpected error validating %#v with %s constant validator: %v", value, c.desc, err)
+			} else if jsonString(result) != jsonString(c.constant) {
+				t.Errorf("expected result of validating %#v with %s constant validator to be the constant but it is: %#v", value, c.desc, result)
+			}
+		}
+
+		for _, value := range c.rejected {
+			_, err := validator.Validate("", value)
+			AssertFieldError(t, err, "", "invalid")
+		}
+	}
+
+	// Test that the result is the constant as given.
+	if result, err := Const(constTestVersion{Major: 1}).Validate("", map[string]interface{}{"major": 1.0, "tags": nil}); err != nil {
+		t.Errorf("unexpected error validating object with struct constant validator: %v", err)
+	} else if _, ok := result.(constTestVersion); !ok {
+		t.Errorf("expected result of struct constant validator to be the struct but it is: %#v", result)
+	}
+
+	// Test the expected parameter.
+	_, err := Const(map[string]interface{}{"a": 1}).Validate("", "a")
+	if validationErr, ok := err.(*ValidationError); !ok || jsonString(validationErr.Fields[0].Param("expected")) != `{"a":1}` || validationErr.Fields[0].Message != `Value must be {"a":1}` {
+		t.Errorf("expected error with expected parameter but got: %v", err)
+	}
+
+	// Test that invalid constants panic.
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected panic from constant which is not representable as JSON")
+			}
+		}()
+
+		Const(func() {})
+	}()
+}
+
+func TestConstMissing(t *testing.T) {
+	// Test that missing values are accepted unless required.
+	if result, err := Const("v1").Validate("", nil); err != nil || result != nil {
+		t.Errorf("unexpected result from validating missing value with constant validator: %v, %v", result, err)
+	}
+
+	_, err := Const("v1").Required().Validate("", nil)
+	AssertFieldError(t, err, "", "required")
+
+	AssertObjectValidationResult(
+		t,
+		"object without constant property",
+		"object validator with constant property",
+		Object(Prop("version", Const("v1"))),
+		map[string]interface{}{},
+		map[string]interface{}{"version": nil},
+	)
+	AssertObjectValidationFails(
+		t,
+		"object without constant property",
+		"object validator with required constant property",
+		Object(Prop("version", Const("v1").Required())),
+		map[string]interface{}{},
+	)
+
+	// Test that a nil constant only accepts null, even when required.
+	for _, validator := range []*ConstValidator{Const(nil), Const(nil).Required()} {
+		if _, err := validator.Validate("", nil); err != nil {
+			t.Errorf("unexpected error validating null with nil constant validator: %v", err)
+		}
+
+		for _, value := range []interface{}{0.0, "", false, []interface{}{}} {
+			_, err := validator.Validate("", value)
+			AssertFieldError(t, err, "", "invalid")
+		}
+	}
+}
+
+func TestNull(t *testing.T) {
+	if result, err := Null().Validate("", nil); err != nil || result != nil {
+		t.Errorf("unexpected result from validating null with null validator: %v, %v", result, err)
+	}
+
+	for _, value := range []interface{}{0.0, "", false, "null", map[string]interface{}{}} {
+		_, err := Null().Validate("", value)
+		AssertFieldError(t, err, "", "invalid_type")
+	}
+
+	AssertObjectValidationResult(
+		t,
+		"object without null property",
+		"object validator with null property",
+		Object(Prop("deleted", Null())),
+		map[string]interface{}{},
+		map[string]interface{}{"deleted": nil},
+	)
+}
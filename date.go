@@ -0,0 +1,485 @@
+package jsonvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Civil date.
+//
+// A civil date without a time or time zone.
+type CivilDate struct {
+	Year int
+	Month time.Month
+	Day int
+}
+
+// Civil date of time.
+func CivilDateOf(t time.Time) CivilDate {
+	year, month, day := t.Date()
+	return CivilDate{year, month, day}
+}
+
+// Before date.
+func (d CivilDate) Before(o CivilDate) bool {
+	if d.Year != o.Year {
+		return d.Year < o.Year
+	} else if d.Month != o.Month {
+		return d.Month < o.Month
+	}
+
+	return d.Day < o.Day
+}
+
+// After date.
+func (d CivilDate) After(o CivilDate) bool {
+	return o.Before(d)
+}
+
+// Add days to date.
+func (d CivilDate) AddDays(days int) CivilDate {
+	return CivilDateOf(time.Date(d.Year, d.Month, d.Day + days, 0, 0, 0, 0, time.UTC))
+}
+
+// String representation of the date.
+//
+// Returns the date in ISO 8601 format.
+func (d CivilDate) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+func (d CivilDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *CivilDate) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return err
+	}
+
+	*d = CivilDateOf(t)
+	return nil
+}
+
+type DateValueValidator func(value CivilDate) (CivilDate, *ValueError)
+
+// Date validator.
+//
+// Validates dates, by default in ISO 8601 format, returning a CivilDate.
+type DateValidator struct {
+	required bool
+	defaultFunc func() CivilDate
+	layouts []string
+	location *time.Location
+	now func() time.Time
+	minFromToday *int
+	maxFromToday *int
+	valueValidators []DateValueValidator
+}
+
+func (v *DateValidator) clone() *DateValidator {
+	return &DateValidator{
+		required: v.required,
+		defaultFunc: v.defaultFunc,
+		layouts: v.layouts,
+		location: v.location,
+		now: v.now,
+		minFromToday: v.minFromToday,
+		maxFromToday: v.maxFromToday,
+		valueValidators: v.valueValidators,
+	}
+}
+
+func (v *DateValidator) Required() *DateValidator {
+	nv := v.clone()
+	nv.required = true
+	return nv
+}
+
+func (v *DateValidator) Default(value CivilDate) *DateValidator {
+	return v.DefaultFunc(func() CivilDate {
+		return value
+	})
+}
+
+func (v *DateValidator) DefaultFunc(fn func() CivilDate) *DateValidator {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+func (v *DateValidator) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
+// Layouts.
+//
+// Sets the layouts accepted, in the format of time.Parse.
+func (v *DateValidator) Layouts(layouts ...string) *DateValidator {
+	nv := v.clone()
+	nv.layouts = layouts
+	return nv
+}
+
+// In location.
+//
+// Sets the location used to determine the current date for relative bounds.
+// Defaults to UTC.
+func (v *DateValidator) In(location *time.Location) *DateValidator {
+	nv := v.clone()
+	nv.location = location
+	return nv
+}
+
+// Now.
+//
+// Sets the function used to determine the current time for relative bounds.
+// Defaults to time.Now.
+func (v *DateValidator) Now(now func() time.Time) *DateValidator {
+	nv := v.clone()
+	nv.now = now
+	return nv
+}
+
+func (v *DateValidator) ValidateValue(dvv DateValueValidator) *DateValidator {
+	nv := v.clone()
+	nv.valueValidators = make([]DateValueValidator, 0, len(v.valueValidators) + 1)
+	nv.valueValidators = append(nv.valueValidators, v.valueValidators...)
+	nv.valueValidators = append(nv.valueValidators, dvv)
+	return nv
+}
+
+func (v *DateValidator) Min(minValue CivilDate) *DateValidator {
+	return v.ValidateValue(func(value CivilDate) (CivilDate, *ValueError) {
+		if value.Before(minValue) {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be on or after %s", minValue),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func (v *DateValidator) Max(maxValue CivilDate) *DateValidator {
+	return v.ValidateValue(func(value CivilDate) (CivilDate, *ValueError) {
+		if value.After(maxValue) {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be on or before %s", maxValue),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+// Minimum relative to today.
+//
+// Requires the value to be on or after the current date plus the number of
+// days, which may be negative.
+func (v *DateValidator) MinFromToday(days int) *DateValidator {
+	nv := v.clone()
+	nv.minFromToday = &days
+	return nv
+}
+
+// Maximum relative to today.
+//
+// Requires the value to be on or before the current date plus the number of
+// days, which may be negative.
+func (v *DateValidator) MaxFromToday(days int) *DateValidator {
+	nv := v.clone()
+	nv.maxFromToday = &days
+	return nv
+}
+
+func (v *DateValidator) NotInPast() *DateValidator {
+	return v.MinFromToday(0)
+}
+
+func (v *DateValidator) NotInFuture() *DateValidator {
+	return v.MaxFromToday(0)
+}
+
+func (v *DateValidator) today() CivilDate {
+	now := time.Now
+	if v.now != nil {
+		now = v.now
+	}
+
+	location := v.location
+	if location == nil {
+		location = time.UTC
+	}
+
+	return CivilDateOf(now().In(location))
+}
+
+func (v *DateValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	// Test if the value is nil, in which case we can short-circuit to checking
+	// if the value is required.
+	if value == nil {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
+		return nil, nil
+	}
+
+	// Parse the value.
+	strValue, ok := value.(string)
+	var timeValue time.Time
+
+	if ok {
+		timeValue, ok = parseTime(strValue, v.layouts, time.UTC)
+	}
+
+	if !ok {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid_date",
+			Message: "Value must be a date",
+		})
+	}
+
+	dateValue := CivilDateOf(timeValue)
+
+	// Validate relative bounds.
+	if v.minFromToday != nil && dateValue.Before(v.today().AddDays(*v.minFromToday)) {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid",
+			Message: "Value is too early",
+		})
+	}
+
+	if v.maxFromToday != nil && dateValue.After(v.today().AddDays(*v.maxFromToday)) {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid",
+			Message: "Value is too late",
+		})
+	}
+
+	// Validate the value.
+	for _, valueValidator := range v.valueValidators {
+		var err *ValueError
+		if dateValue, err = valueValidator(dateValue); err != nil {
+			return nil, ValidationErrorAtPath(path, *err)
+		}
+	}
+
+	return dateValue, nil
+}
+
+func Date() *DateValidator {
+	return &DateValidator{
+		layouts: []string{"2006-01-02"},
+	}
+}
+
+// Civil time.
+//
+// A civil time without a date or time zone.
+type CivilTime struct {
+	Hour int
+	Minute int
+	Second int
+	Nanosecond int
+}
+
+// Civil time of time.
+func CivilTimeOf(t time.Time) CivilTime {
+	return CivilTime{t.Hour(), t.Minute(), t.Second(), t.Nanosecond()}
+}
+
+func (t CivilTime) duration() time.Duration {
+	return time.Duration(t.Hour) * time.Hour + time.Duration(t.Minute) * time.Minute + time.Duration(t.Second) * time.Second + time.Duration(t.Nanosecond)
+}
+
+// Before time of day.
+func (t CivilTime) Before(o CivilTime) bool {
+	return t.duration() < o.duration()
+}
+
+// After time of day.
+func (t CivilTime) After(o CivilTime) bool {
+	return t.duration() > o.duration()
+}
+
+// String representation of the time of day.
+//
+// Returns the time in ISO 8601 format.
+func (t CivilTime) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond != 0 {
+		s += fmt.Sprintf(".%09d", t.Nanosecond)
+	}
+
+	return s
+}
+
+func (t CivilTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *CivilTime) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := time.Parse("15:04:05.999999999", s)
+	if err != nil {
+		return err
+	}
+
+	*t = CivilTimeOf(parsed)
+	return nil
+}
+
+type TimeOfDayValueValidator func(value CivilTime) (CivilTime, *ValueError)
+
+// Time of day validator.
+//
+// Validates times of day, by default in ISO 8601 format with optional seconds,
+// returning a CivilTime.
+type TimeOfDayValidator struct {
+	required bool
+	defaultFunc func() CivilTime
+	layouts []string
+	valueValidators []TimeOfDayValueValidator
+}
+
+func (v *TimeOfDayValidator) clone() *TimeOfDayValidator {
+	return &TimeOfDayValidator{
+		required: v.required,
+		defaultFunc: v.defaultFunc,
+		layouts: v.layouts,
+		valueValidators: v.valueValidators,
+	}
+}
+
+func (v *TimeOfDayValidator) Required() *TimeOfDayValidator {
+	nv := v.clone()
+	nv.required = true
+	return nv
+}
+
+func (v *TimeOfDayValidator) Default(value CivilTime) *TimeOfDayValidator {
+	return v.DefaultFunc(func() CivilTime {
+		return value
+	})
+}
+
+func (v *TimeOfDayValidator) DefaultFunc(fn func() CivilTime) *TimeOfDayValidator {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+func (v *TimeOfDayValidator) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
+// Layouts.
+//
+// Sets the layouts accepted, in the format of time.Parse.
+func (v *TimeOfDayValidator) Layouts(layouts ...string) *TimeOfDayValidator {
+	nv := v.clone()
+	nv.layouts = layouts
+	return nv
+}
+
+func (v *TimeOfDayValidator) ValidateValue(tvv TimeOfDayValueValidator) *TimeOfDayValidator {
+	nv := v.clone()
+	nv.valueValidators = make([]TimeOfDayValueValidator, 0, len(v.valueValidators) + 1)
+	nv.valueValidators = append(nv.valueValidators, v.valueValidators...)
+	nv.valueValidators = append(nv.valueValidators, tvv)
+	return nv
+}
+
+func (v *TimeOfDayValidator) Min(minValue CivilTime) *TimeOfDayValidator {
+	return v.ValidateValue(func(value CivilTime) (CivilTime, *ValueError) {
+		if value.Before(minValue) {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be at or after %s", minValue),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func (v *TimeOfDayValidator) Max(maxValue CivilTime) *TimeOfDayValidator {
+	return v.ValidateValue(func(value CivilTime) (CivilTime, *ValueError) {
+		if value.After(maxValue) {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be at or before %s", maxValue),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func (v *TimeOfDayValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	// Test if the value is nil, in which case we can short-circuit to checking
+	// if the value is required.
+	if value == nil {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
+		return nil, nil
+	}
+
+	// Parse the value.
+	strValue, ok := value.(string)
+	var timeValue time.Time
+
+	if ok {
+		timeValue, ok = parseTime(strValue, v.layouts, time.UTC)
+	}
+
+	if !ok {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid_time",
+			Message: "Value must be a time of day",
+		})
+	}
+
+	todValue := CivilTimeOf(timeValue)
+
+	// Validate the value.
+	for _, valueValidator := range v.valueValidators {
+		var err *ValueError
+		if todValue, err = valueValidator(todValue); err != nil {
+			return nil, ValidationErrorAtPath(path, *err)
+		}
+	}
+
+	return todValue, nil
+}
+
+func TimeOfDay() *TimeOfDayValidator {
+	return &TimeOfDayValidator{
+		layouts: []string{"15:04:05.999999999", "15:04"},
+	}
+}
@@ -0,0 +1,303 @@
+package jsonvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Decimal value validator.
+//
+// Unlike the value validators of other types, decimal value validators cannot
+// transform the value, as the textual representation would be lost.
+type DecimalValueValidator func(value *big.Rat) *ValueError
+
+// Decimal validator.
+//
+// Validates decimal numbers exactly, preserving their textual
+// representation. Only json.Number and string values are accepted, as float64
+// values may already have been rounded when decoded. The result is a
+// json.Number unless another type is set with UnmarshalTo.
+type DecimalValidator struct {
+	required bool
+	defaultFunc func() interface{}
+	maxScale int
+	maxPrecision int
+	coercer Coercer
+	valueValidators []DecimalValueValidator
+	targetType reflect.Type
+}
+
+func (v *DecimalValidator) clone() *DecimalValidator {
+	return &DecimalValidator{
+		required: v.required,
+		defaultFunc: v.defaultFunc,
+		maxScale: v.maxScale,
+		maxPrecision: v.maxPrecision,
+		coercer: v.coercer,
+		valueValidators: v.valueValidators,
+		targetType: v.targetType,
+	}
+}
+
+func (v *DecimalValidator) Required() *DecimalValidator {
+	nv := v.clone()
+	nv.required = true
+	return nv
+}
+
+func (v *DecimalValidator) Default(value interface{}) *DecimalValidator {
+	return v.DefaultFunc(func() interface{} {
+		return value
+	})
+}
+
+func (v *DecimalValidator) DefaultFunc(fn func() interface{}) *DecimalValidator {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+func (v *DecimalValidator) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
+// Maximum scale.
+//
+// Limits the number of digits after the decimal point.
+func (v *DecimalValidator) MaxScale(maxScale int) *DecimalValidator {
+	nv := v.clone()
+	nv.maxScale = maxScale
+	return nv
+}
+
+// Maximum precision.
+//
+// Limits the total number of significant digits.
+func (v *DecimalValidator) MaxPrecision(maxPrecision int) *DecimalValidator {
+	nv := v.clone()
+	nv.maxPrecision = maxPrecision
+	return nv
+}
+
+// Coerce.
+//
+// Sets the coercer used instead of the default coercer.
+func (v *DecimalValidator) Coerce(coercer Coercer) *DecimalValidator {
+	nv := v.clone()
+	nv.coercer = coercer
+	return nv
+}
+
+func (v *DecimalValidator) inheritCoercer(coercer Coercer) Validator {
+	if v.coercer != nil {
+		return v
+	}
+
+	return v.Coerce(coercer)
+}
+
+// Unmarshal to type.
+//
+// Supported types are big.Rat, types implementing encoding.TextUnmarshaler
+// and string-backed types, as well as pointers to them.
+func (v *DecimalValidator) UnmarshalTo(typ interface{}) *DecimalValidator {
+	nv := v.clone()
+
+	if refTyp, ok := typ.(reflect.Type); ok {
+		nv.targetType = refTyp
+	} else {
+		nv.targetType = reflect.TypeOf(typ)
+	}
+
+	return nv
+}
+
+func (v *DecimalValidator) ValidateValue(dvv DecimalValueValidator) *DecimalValidator {
+	nv := v.clone()
+	nv.valueValidators = make([]DecimalValueValidator, 0, len(v.valueValidators) + 1)
+	nv.valueValidators = append(nv.valueValidators, v.valueValidators...)
+	nv.valueValidators = append(nv.valueValidators, dvv)
+	return nv
+}
+
+func (v *DecimalValidator) Min(minValue string) *DecimalValidator {
+	minRat := mustParseDecimal(minValue)
+
+	return v.ValidateValue(func(value *big.Rat) *ValueError {
+		if value.Cmp(minRat) < 0 {
+			return &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be at least %s", minValue),
+			}
+		}
+
+		return nil
+	})
+}
+
+func (v *DecimalValidator) Max(maxValue string) *DecimalValidator {
+	maxRat := mustParseDecimal(maxValue)
+
+	return v.ValidateValue(func(value *big.Rat) *ValueError {
+		if value.Cmp(maxRat) > 0 {
+			return &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be at most %s", maxValue),
+			}
+		}
+
+		return nil
+	})
+}
+
+func (v *DecimalValidator) MultipleOf(factor string) *DecimalValidator {
+	factorRat := mustParseDecimal(factor)
+	if factorRat.Sign() <= 0 {
+		panic("jsonvalid: decimal factor must be positive")
+	}
+
+	return v.ValidateValue(func(value *big.Rat) *ValueError {
+		if !new(big.Rat).Quo(value, factorRat).IsInt() {
+			return &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be a multiple of %s", factor),
+			}
+		}
+
+		return nil
+	})
+}
+
+func (v *DecimalValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	// Test if the value is nil, in which case we can short-circuit to checking
+	// if the value is required.
+	if value == nil {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
+		return nil, nil
+	}
+
+	// Resolve the textual representation of the value.
+	var text string
+
+	switch tv := coerce(v.coercer, CoerceToNumber, value).(type) {
+	case json.Number:
+		text = string(tv)
+	case string:
+		text = strings.TrimPrefix(tv, "+")
+	case float64:
+		// The text the float64 was decoded from is lost, so the value may
+		// already have been rounded. Values must be decoded with UseNumber.
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid_type",
+			Message: "Value must be an exact decimal number",
+		})
+	default:
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid_type",
+			Message: "Value must be a decimal number",
+		})
+	}
+
+	ratValue, ok := parseExactNumber(text)
+	if !ok {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid_type",
+			Message: "Value must be a decimal number",
+		})
+	}
+
+	// Validate the scale and precision.
+	precision, scale := decimalDigits(text)
+
+	if v.maxScale >= 0 && scale > v.maxScale {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid",
+			Message: fmt.Sprintf("Value must have at most %d decimal place(s)", v.maxScale),
+		})
+	}
+
+	if v.maxPrecision >= 0 && precision > v.maxPrecision {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid",
+			Message: fmt.Sprintf("Value must have at most %d significant digit(s)", v.maxPrecision),
+		})
+	}
+
+	// Validate the value.
+	for _, valueValidator := range v.valueValidators {
+		if err := valueValidator(ratValue); err != nil {
+			return nil, ValidationErrorAtPath(path, *err)
+		}
+	}
+
+	// Unmarshal if necessary.
+	if v.targetType == nil {
+		return json.Number(text), nil
+	}
+
+	return unmarshalDecimal(text, ratValue, v.targetType)
+}
+
+func Decimal() *DecimalValidator {
+	return &DecimalValidator{
+		maxScale: -1,
+		maxPrecision: -1,
+	}
+}
+
+func mustParseDecimal(s string) *big.Rat {
+	value, ok := parseExactNumber(s)
+	if !ok {
+		panic(fmt.Sprintf("jsonvalid: invalid decimal %q", s))
+	}
+
+	return value
+}
+
+// Decimal digits.
+//
+// Determines the number of significant digits and the number of digits after
+// the decimal point of a numeric string.
+func decimalDigits(text string) (precision, scale int) {
+	text = strings.TrimLeft(text, "+-")
+
+	mantissa, exp := text, 0
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		mantissa = text[:i]
+		exp, _ = strconv.Atoi(text[i+1:])
+	}
+
+	intPart, fracPart := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		intPart, fracPart = mantissa[:i], mantissa[i+1:]
+	}
+
+	scale = len(fracPart) - exp
+	if scale < 0 {
+		scale = 0
+	}
+
+	digits := strings.TrimLeft(intPart + fracPart, "0")
+	precision = len(digits)
+
+	if len(digits) > 0 && exp > len(fracPart) {
+		precision += exp - len(fracPart)
+	}
+
+	if precision == 0 {
+		precision = 1
+	}
+
+	return precision, scale
+}
@@ -0,0 +1,38 @@
+package jsonvalid
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+	validator := Decimal().MaxPrecision(25).MaxScale(2)
+
+	result, err := validator.Validate("", json.Number("12345678901234567890.12"))
+	if err != nil || result != json.Number("12345678901234567890.12") {
+		t.Errorf("unexpected result from validating exact decimal: %v, %v", result, err)
+	}
+
+	result, err = validator.Validate("", "19.99")
+	if err != nil || result != json.Number("19.99") {
+		t.Errorf("unexpected result from validating decimal string: %v, %v", result, err)
+	}
+
+	rejected := []interface{}{12345678901234567890.12, 19.99, json.Number("1.234"), "1e", "abc"}
+	for _, value := range rejected {
+		if _, err := validator.Validate("", value); err == nil {
+			t.Errorf("expected error from validating %#v with decimal validator", value)
+		}
+	}
+
+	// Test unmarshaling into an exact rational number.
+	result, err = Decimal().UnmarshalTo(&big.Rat{}).Validate("", json.Number("0.1"))
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling decimal: %v", err)
+	}
+
+	if rat := result.(*big.Rat); rat.Cmp(big.NewRat(1, 10)) != 0 {
+		t.Errorf("expected 1/10 from unmarshaling decimal but got: %v", rat)
+	}
+}
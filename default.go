@@ -0,0 +1,134 @@
+package jsonvalid
+
+// Defaulter.
+//
+// Implemented by validators with a default value, set with Default or
+// DefaultFunc. The default is returned when the value is null or missing,
+// unless the value is required. Default values are returned as is, so
+// DefaultFunc should be used for mutable or time-dependent defaults.
+type defaulter interface {
+	hasDefault() bool
+}
+
+// Validation tracker.
+//
+// Collects the paths of values which were defaulted during validation, refers
+// to the original JSON of the value when it was parsed from a request, and
+// counts the depth of nested lazy validators. Containers pass a tracker for
+// each element or property on to nested validators with Prop and Elem.
+type validationTracker struct {
+	defaults *[]Path
+	rawDocument *rawJSONDocument
+	parent *validationTracker
+	prop string
+	elem int
+	lazyDepth int
+	formPaths bool
+}
+
+// Path of property.
+//
+// Returns the path of a property, in bracket notation when validating form
+// values, so that the paths of errors match the form field names.
+func (t *validationTracker) propPath(path Path, name string) Path {
+	if t != nil && t.formPaths && path != "" {
+		return Path(string(path) + "[" + name + "]")
+	}
+
+	return path.Prop(name)
+}
+
+// Tracker for property.
+func (t *validationTracker) Prop(name string) *validationTracker {
+	if t == nil {
+		return nil
+	}
+
+	return t.child(name, -1)
+}
+
+// Tracker for element.
+func (t *validationTracker) Elem(index int) *validationTracker {
+	if t == nil {
+		return nil
+	}
+
+	return t.child("", index)
+}
+
+func (t *validationTracker) child(prop string, elem int) *validationTracker {
+	child := &validationTracker{
+		defaults: t.defaults,
+		lazyDepth: t.lazyDepth,
+		formPaths: t.formPaths,
+	}
+
+	// Only refer to the parent when there is a raw document to look up.
+	if t.rawDocument != nil {
+		child.rawDocument = t.rawDocument
+		child.parent = t
+		child.prop = prop
+		child.elem = elem
+	}
+
+	return child
+}
+
+// Raw JSON of value.
+//
+// Looks up the node of the value in the raw document, indexing the document
+// on first use. Returns nil if there is no raw document.
+func (t *validationTracker) raw() *rawJSONNode {
+	if t == nil || t.rawDocument == nil {
+		return nil
+	} else if t.parent == nil {
+		return t.rawDocument.root()
+	} else if t.elem >= 0 {
+		return t.parent.raw().elem(t.elem)
+	}
+
+	return t.parent.raw().prop(t.prop)
+}
+
+// Tracking validator.
+//
+// Implemented by validators which contain other validators, so that the
+// tracker is passed on to nested validators, and by validators which use the
+// tracker themselves.
+type trackingValidator interface {
+	validateTracking(path Path, value interface{}, tracker *validationTracker) (interface{}, error)
+}
+
+func validateTracking(validator Validator, path Path, value interface{}, tracker *validationTracker) (interface{}, error) {
+	if tracker == nil {
+		return validator.Validate(path, value)
+	}
+
+	if value == nil {
+		if d, ok := validator.(defaulter); ok && d.hasDefault() && tracker.defaults != nil {
+			*tracker.defaults = append(*tracker.defaults, path)
+		}
+	}
+
+	if tv, ok := validator.(trackingValidator); ok {
+		return tv.validateTracking(path, value, tracker)
+	}
+
+	return validator.Validate(path, value)
+}
+
+// Validate tracking defaults.
+//
+// Validates the value, additionally returning the paths of all values which
+// were null or missing and replaced by a default value, including values
+// within defaulted objects.
+func ValidateTrackingDefaults(validator Validator, path Path, value interface{}) (interface{}, []Path, error) {
+	var defaults []Path
+
+	result, err := validateTracking(validator, path, value, &validationTracker{defaults: &defaults})
+	if err != nil {
+		return result, nil, err
+	}
+
+	return result, defaults, nil
+}
@@ -0,0 +1,448 @@
+package jsonvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calendar duration.
+//
+// An ISO 8601 duration, where years, months and days are kept separate from
+// the clock duration, as their length depends on the time they are added to.
+type CalendarDuration struct {
+	Years int
+	Months int
+	Days int
+	Clock time.Duration
+}
+
+// Is calendar.
+//
+// Tests if the duration has year or month components.
+func (d CalendarDuration) IsCalendar() bool {
+	return d.Years != 0 || d.Months != 0
+}
+
+// Add to time.
+func (d CalendarDuration) AddTo(t time.Time) time.Time {
+	return t.AddDate(d.Years, d.Months, d.Days).Add(d.Clock)
+}
+
+// Approximate duration.
+//
+// Returns the duration using the average lengths of years and months, and
+// days of 24 hours.
+func (d CalendarDuration) Approx() time.Duration {
+	const day = 24 * time.Hour
+	const year = time.Duration(365.2425 * float64(day))
+
+	return time.Duration(d.Years) * year + time.Duration(d.Months) * (year / 12) + time.Duration(d.Days) * day + d.Clock
+}
+
+// String representation of the duration.
+//
+// Returns the duration in ISO 8601 format. Negative durations are prefixed
+// with a minus sign, while components of durations with mixed signs are
+// signed individually, e.g. P1Y-1D.
+func (d CalendarDuration) String() string {
+	if d == (CalendarDuration{}) {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+
+	years, months, days, clock := d.Years, d.Months, d.Days, d.Clock
+	if years <= 0 && months <= 0 && days <= 0 && clock <= 0 {
+		b.WriteByte('-')
+		years, months, days, clock = -years, -months, -days, -clock
+	}
+
+	b.WriteByte('P')
+	if years != 0 {
+		fmt.Fprintf(&b, "%dY", years)
+	}
+	if months != 0 {
+		fmt.Fprintf(&b, "%dM", months)
+	}
+	if days != 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+
+	if clock != 0 {
+		b.WriteByte('T')
+
+		if hours := clock / time.Hour; hours != 0 {
+			fmt.Fprintf(&b, "%dH", hours)
+		}
+		if minutes := clock % time.Hour / time.Minute; minutes != 0 {
+			fmt.Fprintf(&b, "%dM", minutes)
+		}
+		if seconds := clock % time.Minute; seconds != 0 {
+			b.WriteString(strconv.FormatFloat(seconds.Seconds(), 'f', -1, 64))
+			b.WriteByte('S')
+		}
+	}
+
+	return b.String()
+}
+
+func (d CalendarDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *CalendarDuration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, ok := parseISODuration(s)
+	if !ok {
+		return fmt.Errorf("invalid ISO 8601 duration: %q", s)
+	}
+
+	*d = parsed
+	return nil
+}
+
+// Duration validator.
+//
+// Validates ISO 8601 durations, such as PT15M or P1Y2M, returning a
+// CalendarDuration, or a time.Duration if Clock is used.
+type DurationValidator struct {
+	required bool
+	defaultFunc func() interface{}
+	clock bool
+	minValue *time.Duration
+	maxValue *time.Duration
+}
+
+func (v *DurationValidator) clone() *DurationValidator {
+	return &DurationValidator{
+		required: v.required,
+		defaultFunc: v.defaultFunc,
+		clock: v.clock,
+		minValue: v.minValue,
+		maxValue: v.maxValue,
+	}
+}
+
+func (v *DurationValidator) Required() *DurationValidator {
+	nv := v.clone()
+	nv.required = true
+	return nv
+}
+
+func (v *DurationValidator) Default(value interface{}) *DurationValidator {
+	return v.DefaultFunc(func() interface{} {
+		return value
+	})
+}
+
+func (v *DurationValidator) DefaultFunc(fn func() interface{}) *DurationValidator {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+func (v *DurationValidator) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
+// Clock.
+//
+// Rejects durations with year or month components, and returns the duration
+// as a time.Duration with days of 24 hours.
+func (v *DurationValidator) Clock() *DurationValidator {
+	nv := v.clone()
+	nv.clock = true
+	return nv
+}
+
+// Minimum duration.
+//
+// Durations with calendar components are compared by their approximate
+// duration.
+func (v *DurationValidator) Min(minValue time.Duration) *DurationValidator {
+	nv := v.clone()
+	nv.minValue = &minValue
+	return nv
+}
+
+// Maximum duration.
+//
+// Durations with calendar components are compared by their approximate
+// duration.
+func (v *DurationValidator) Max(maxValue time.Duration) *DurationValidator {
+	nv := v.clone()
+	nv.maxValue = &maxValue
+	return nv
+}
+
+func (v *DurationValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	// Test if the value is nil, in which case we can short-circuit to checking
+	// if the value is required.
+	if value == nil {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
+		return nil, nil
+	}
+
+	// Parse the value.
+	strValue, ok := value.(string)
+	var durationValue CalendarDuration
+
+	if ok {
+		durationValue, ok = parseISODuration(strValue)
+	}
+
+	if !ok || (v.clock && durationValue.IsCalendar()) {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid_duration",
+			Message: "Value must be an ISO 8601 duration",
+		})
+	}
+
+	// Validate the bounds.
+	if valueErr := validateDurationBounds(durationValue.Approx(), v.minValue, v.maxValue); valueErr != nil {
+		return nil, ValidationErrorAtPath(path, *valueErr)
+	}
+
+	if v.clock {
+		return durationValue.Approx(), nil
+	}
+
+	return durationValue, nil
+}
+
+func Duration() *DurationValidator {
+	return &DurationValidator{}
+}
+
+// Interval.
+type Interval struct {
+	Start time.Time `json:"start"`
+	End time.Time `json:"end"`
+}
+
+// Duration of the interval.
+func (i Interval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// Interval validator.
+//
+// Validates ISO 8601 intervals given as start/end, start/duration or
+// duration/end, where times are RFC 3339 timestamps or dates. Returns an
+// Interval.
+type IntervalValidator struct {
+	required bool
+	defaultFunc func() Interval
+	minValue *time.Duration
+	maxValue *time.Duration
+}
+
+func (v *IntervalValidator) clone() *IntervalValidator {
+	return &IntervalValidator{
+		required: v.required,
+		defaultFunc: v.defaultFunc,
+		minValue: v.minValue,
+		maxValue: v.maxValue,
+	}
+}
+
+func (v *IntervalValidator) Required() *IntervalValidator {
+	nv := v.clone()
+	nv.required = true
+	return nv
+}
+
+func (v *IntervalValidator) Default(value Interval) *IntervalValidator {
+	return v.DefaultFunc(func() Interval {
+		return value
+	})
+}
+
+func (v *IntervalValidator) DefaultFunc(fn func() Interval) *IntervalValidator {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+func (v *IntervalValidator) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
+// Minimum duration of the interval.
+func (v *IntervalValidator) MinDuration(minValue time.Duration) *IntervalValidator {
+	nv := v.clone()
+	nv.minValue = &minValue
+	return nv
+}
+
+// Maximum duration of the interval.
+func (v *IntervalValidator) MaxDuration(maxValue time.Duration) *IntervalValidator {
+	nv := v.clone()
+	nv.maxValue = &maxValue
+	return nv
+}
+
+func (v *IntervalValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	// Test if the value is nil, in which case we can short-circuit to checking
+	// if the value is required.
+	if value == nil {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
+		return nil, nil
+	}
+
+	// Parse the value.
+	strValue, ok := value.(string)
+	var intervalValue Interval
+
+	if ok {
+		intervalValue, ok = parseISOInterval(strValue)
+	}
+
+	if !ok {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid_interval",
+			Message: "Value must be an ISO 8601 interval",
+		})
+	}
+
+	// Validate the bounds.
+	if valueErr := validateDurationBounds(intervalValue.Duration(), v.minValue, v.maxValue); valueErr != nil {
+		return nil, ValidationErrorAtPath(path, *valueErr)
+	}
+
+	return intervalValue, nil
+}
+
+func TimeInterval() *IntervalValidator {
+	return &IntervalValidator{}
+}
+
+func validateDurationBounds(d time.Duration, minValue, maxValue *time.Duration) *ValueError {
+	if minValue != nil && d < *minValue {
+		return &ValueError{
+			Code: "invalid",
+			Message: fmt.Sprintf("Duration must be at least %s", *minValue),
+		}
+	}
+
+	if maxValue != nil && d > *maxValue {
+		return &ValueError{
+			Code: "invalid",
+			Message: fmt.Sprintf("Duration must be at most %s", *maxValue),
+		}
+	}
+
+	return nil
+}
+
+var isoDurationRegexp = regexp.MustCompile(`^([+-])?P(?:([+-]?[0-9]{1,9})Y)?(?:([+-]?[0-9]{1,9})M)?(?:([+-]?[0-9]{1,9})W)?(?:([+-]?[0-9]{1,9})D)?(?:T(?:([+-]?[0-9]{1,9})H)?(?:([+-]?[0-9]{1,9})M)?(?:([+-]?[0-9]{1,9}(?:[.,][0-9]{1,9})?)S)?)?$`)
+
+// Parse ISO 8601 duration.
+//
+// Components may be signed individually, as in ISO 8601-2, unless the
+// duration itself is signed.
+func parseISODuration(value string) (CalendarDuration, bool) {
+	var d CalendarDuration
+
+	match := isoDurationRegexp.FindStringSubmatch(value)
+	if match == nil || strings.HasSuffix(value, "P") || strings.HasSuffix(value, "T") {
+		return d, false
+	}
+
+	if match[1] != "" && strings.ContainsAny(value[1:], "+-") {
+		return d, false
+	}
+
+	atoi := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+
+	d.Years = atoi(match[2])
+	d.Months = atoi(match[3])
+	d.Days = atoi(match[4]) * 7 + atoi(match[5])
+
+	hours := atoi(match[6])
+	minutes := atoi(match[7])
+	seconds, _ := strconv.ParseFloat(strings.Replace(match[8], ",", ".", 1), 64)
+
+	// Reject durations which cannot be represented, even approximately.
+	clock := float64(hours) * float64(time.Hour) + float64(minutes) * float64(time.Minute) + seconds * float64(time.Second)
+	total := clock + (float64(d.Years) * 365.2425 + float64(d.Months) * 365.2425 / 12 + float64(d.Days)) * float64(24 * time.Hour)
+	if math.Abs(total) >= math.MaxInt64 {
+		return d, false
+	}
+
+	d.Clock = time.Duration(math.Round(clock))
+
+	if match[1] == "-" {
+		d.Years, d.Months, d.Days, d.Clock = -d.Years, -d.Months, -d.Days, -d.Clock
+	}
+
+	return d, true
+}
+
+// Parse ISO 8601 interval.
+func parseISOInterval(value string) (Interval, bool) {
+	var i Interval
+
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 {
+		return i, false
+	}
+
+	layouts := []string{time.RFC3339Nano, "2006-01-02"}
+
+	start, startOk := parseTime(parts[0], layouts, time.UTC)
+	end, endOk := parseTime(parts[1], layouts, time.UTC)
+
+	switch {
+	case startOk && endOk:
+		i = Interval{start, end}
+	case startOk:
+		d, ok := parseISODuration(parts[1])
+		if !ok {
+			return i, false
+		}
+
+		i = Interval{start, d.AddTo(start)}
+	case endOk:
+		d, ok := parseISODuration(parts[0])
+		if !ok {
+			return i, false
+		}
+
+		i = Interval{CalendarDuration{-d.Years, -d.Months, -d.Days, -d.Clock}.AddTo(end), end}
+	default:
+		return i, false
+	}
+
+	if i.End.Before(i.Start) {
+		return i, false
+	}
+
+	return i, true
+}
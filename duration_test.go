@@ -0,0 +1,89 @@
+package jsonvalid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarDurationRoundTrip(t *testing.T) {
+	for value, expected := range map[string]CalendarDuration{
+		"PT15M": {Clock: 15 * time.Minute},
+		"P1Y2M": {Years: 1, Months: 2},
+		"P3DT4H5M6.5S": {Days: 3, Clock: 4 * time.Hour + 5 * time.Minute + 6500 * time.Millisecond},
+		"-P1DT2H": {Days: -1, Clock: -2 * time.Hour},
+		"P1Y-1D": {Years: 1, Days: -1},
+		"P1DT-1H-30M": {Days: 1, Clock: -90 * time.Minute},
+		"PT0S": {},
+	} {
+		parsed, ok := parseISODuration(value)
+		if !ok || parsed != expected {
+			t.Errorf("expected %+v from parsing %s but got: %+v, %v", expected, value, parsed, ok)
+		}
+
+		if s := expected.String(); s != value {
+			t.Errorf("expected %s as string representation of %+v but got: %s", value, expected, s)
+		}
+	}
+
+	// Test alternative representations.
+	for value, expected := range map[string]CalendarDuration{
+		"P2W": {Days: 14},
+		"PT1,5S": {Clock: 1500 * time.Millisecond},
+		"+P1D": {Days: 1},
+		"-P1Y2M": {Years: -1, Months: -2},
+	} {
+		if parsed, ok := parseISODuration(value); !ok || parsed != expected {
+			t.Errorf("expected %+v from parsing %s but got: %+v, %v", expected, value, parsed, ok)
+		}
+	}
+
+	for _, value := range []string{"", "P", "PT", "P1H", "PT1D", "1Y", "P1.5Y", "-P-1Y", "P1Y1Y", "P9999999999Y"} {
+		if parsed, ok := parseISODuration(value); ok {
+			t.Errorf("expected error from parsing %q but got: %+v", value, parsed)
+		}
+	}
+}
+
+func TestDurationValidator(t *testing.T) {
+	validator := Duration().Clock().Min(time.Minute).Max(24 * time.Hour)
+
+	if result, err := validator.Validate("", "P1DT0S"); err != nil || result != 24 * time.Hour {
+		t.Errorf("unexpected result from validating clock duration: %v, %v", result, err)
+	}
+
+	for _, value := range []interface{}{"P1M", "PT30S", "P1DT1S", 60.0} {
+		if _, err := validator.Validate("", value); err == nil {
+			t.Errorf("expected error from validating %v with clock duration validator", value)
+		}
+	}
+}
+
+func TestIntervalValidator(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	validator := TimeInterval().MaxDuration(60 * 24 * time.Hour)
+
+	for _, value := range []string{
+		"2026-01-01/2026-02-01",
+		"2026-01-01T00:00:00Z/2026-02-01T00:00:00Z",
+		"2026-01-01/P1M",
+		"P1M/2026-02-01T00:00:00Z",
+		"P31D/2026-02-01",
+	} {
+		result, err := validator.Validate("", value)
+		if err != nil {
+			t.Errorf("unexpected error validating interval %s: %v", value, err)
+			continue
+		}
+
+		if interval := result.(Interval); !interval.Start.Equal(start) || !interval.End.Equal(end) {
+			t.Errorf("expected interval from %v to %v from validating %s but got: %v", start, end, value, interval)
+		}
+	}
+
+	for _, value := range []string{"2026-02-01/2026-01-01", "P1M/P1M", "2026-01-01", "2026-01-01/P1Y", "2026-01-01/-P1D"} {
+		if _, err := validator.Validate("", value); err == nil {
+			t.Errorf("expected error from validating interval %s", value)
+		}
+	}
+}
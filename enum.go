@@ -0,0 +1,175 @@
+package jsonvalid
+
+import (
+	"fmt"
+	"strings"
+)
+
+type enumValue[T any] struct {
+	jsonValue interface{}
+	value T
+	alias bool
+}
+
+// Enum validator.
+//
+// Maps accepted JSON strings or integers to typed Go values, such as the
+// constants of an enum type.
+type EnumValidator[T any] struct {
+	required bool
+	defaultFunc func() T
+	caseInsensitive bool
+	coercer Coercer
+	values []enumValue[T]
+}
+
+func (v *EnumValidator[T]) clone() *EnumValidator[T] {
+	return &EnumValidator[T]{
+		required: v.required,
+		defaultFunc: v.defaultFunc,
+		caseInsensitive: v.caseInsensitive,
+		coercer: v.coercer,
+		values: v.values,
+	}
+}
+
+func (v *EnumValidator[T]) Required() *EnumValidator[T] {
+	nv := v.clone()
+	nv.required = true
+	return nv
+}
+
+func (v *EnumValidator[T]) Default(value T) *EnumValidator[T] {
+	return v.DefaultFunc(func() T {
+		return value
+	})
+}
+
+func (v *EnumValidator[T]) DefaultFunc(fn func() T) *EnumValidator[T] {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+func (v *EnumValidator[T]) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
+// Case insensitive.
+//
+// Matches string values regardless of case.
+func (v *EnumValidator[T]) CaseInsensitive() *EnumValidator[T] {
+	nv := v.clone()
+	nv.caseInsensitive = true
+	return nv
+}
+
+// Coerce.
+//
+// Sets the coercer used instead of the default coercer.
+func (v *EnumValidator[T]) Coerce(coercer Coercer) *EnumValidator[T] {
+	nv := v.clone()
+	nv.coercer = coercer
+	return nv
+}
+
+func (v *EnumValidator[T]) inheritCoercer(coercer Coercer) Validator {
+	if v.coercer != nil {
+		return v
+	}
+
+	return v.Coerce(coercer)
+}
+
+func (v *EnumValidator[T]) withValue(value enumValue[T]) *EnumValidator[T] {
+	nv := v.clone()
+	nv.values = make([]enumValue[T], 0, len(v.values) + 1)
+	nv.values = append(nv.values, v.values...)
+	nv.values = append(nv.values, value)
+	return nv
+}
+
+// String value.
+func (v *EnumValidator[T]) Value(jsonValue string, value T) *EnumValidator[T] {
+	return v.withValue(enumValue[T]{jsonValue: jsonValue, value: value})
+}
+
+// Integer value.
+func (v *EnumValidator[T]) IntValue(jsonValue int64, value T) *EnumValidator[T] {
+	return v.withValue(enumValue[T]{jsonValue: jsonValue, value: value})
+}
+
+// Alias.
+//
+// Accepts an additional string for a value. Aliases are not listed as
+// allowed values.
+func (v *EnumValidator[T]) Alias(jsonValue string, value T) *EnumValidator[T] {
+	return v.withValue(enumValue[T]{jsonValue: jsonValue, value: value, alias: true})
+}
+
+// Allowed values.
+//
+// Returns the accepted JSON values, excluding aliases.
+func (v *EnumValidator[T]) Allowed() []interface{} {
+	allowed := make([]interface{}, 0, len(v.values))
+
+	for _, value := range v.values {
+		if !value.alias {
+			allowed = append(allowed, value.jsonValue)
+		}
+	}
+
+	return allowed
+}
+
+func (v *EnumValidator[T]) Validate(path Path, value interface{}) (interface{}, error) {
+	// Test if the value is nil, in which case we can short-circuit to checking
+	// if the value is required.
+	if value == nil {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
+		return nil, nil
+	}
+
+	// Find the matching value. Strings are matched against string values
+	// before being coerced to match integer values.
+	if strValue, ok := value.(string); ok {
+		for _, candidate := range v.values {
+			if jsonValue, ok := candidate.jsonValue.(string); ok && (jsonValue == strValue || (v.caseInsensitive && strings.EqualFold(jsonValue, strValue))) {
+				return candidate.value, nil
+			}
+		}
+	}
+
+	if intValue, intErr := parseInteger[int64](coerce(v.coercer, CoerceToNumber, value)); intErr == nil {
+		for _, candidate := range v.values {
+			if jsonValue, ok := candidate.jsonValue.(int64); ok && jsonValue == intValue {
+				return candidate.value, nil
+			}
+		}
+	}
+
+	allowed := v.Allowed()
+	allowedStrs := make([]string, len(allowed))
+	for i, value := range allowed {
+		allowedStrs[i] = fmt.Sprint(value)
+	}
+
+	return nil, ValidationErrorAtPath(path, ValueError{
+		Code: "invalid",
+		Message: fmt.Sprintf("Value must be one of: %s", strings.Join(allowedStrs, ", ")),
+		Params: &ErrorParams{
+			"allowed": allowed,
+		},
+	})
+}
+
+func Enum[T any]() *EnumValidator[T] {
+	return &EnumValidator[T]{}
+}
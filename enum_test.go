@@ -0,0 +1,69 @@
+package jsonvalid
+
+import (
+	"reflect"
+	"testing"
+)
+
+type enumTestColor int
+
+const (
+	enumTestRed enumTestColor = iota
+	enumTestGreen
+)
+
+func TestEnum(t *testing.T) {
+	validator := Enum[enumTestColor]().
+		Value("red", enumTestRed).
+		Value("green", enumTestGreen).
+		Alias("crimson", enumTestRed).
+		IntValue(1, enumTestGreen).
+		CaseInsensitive()
+
+	for value, expected := range map[interface{}]enumTestColor{
+		"red": enumTestRed,
+		"GREEN": enumTestGreen,
+		"crimson": enumTestRed,
+		1.0: enumTestGreen,
+	} {
+		if result, err := validator.Validate("", value); err != nil || result != expected {
+			t.Errorf("unexpected result from validating %v with enum validator: %v, %v", value, result, err)
+		}
+	}
+
+	_, err := validator.Validate("", "blue")
+	validationErr, ok := err.(*ValidationError)
+	if !ok || len(validationErr.Fields) != 1 {
+		t.Fatalf("expected validation error from validating unknown enum value but got: %v", err)
+	}
+
+	if allowed := validationErr.Fields[0].Param("allowed"); !reflect.DeepEqual(allowed, []interface{}{"red", "green", int64(1)}) {
+		t.Errorf("expected allowed values without aliases in error parameters but got: %v", allowed)
+	}
+
+	if _, err = Enum[enumTestColor]().Value("red", enumTestRed).Validate("", "RED"); err == nil {
+		t.Errorf("expected error from validating value of different case with case sensitive enum validator")
+	}
+
+	// Test that value errors remain comparable.
+	_, err = validator.Required().Validate("", nil)
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Fields[0].ValueError != ValueErrorRequired {
+		t.Errorf("expected required error from validating missing enum value but got: %v", err)
+	}
+}
+
+func TestOneOf(t *testing.T) {
+	if _, err := String().OneOf("a", "b").Validate("", "b"); err != nil {
+		t.Errorf("unexpected error validating allowed string: %v", err)
+	}
+
+	_, err := String().OneOf("a", "b").Validate("", "c")
+	if validationErr, ok := err.(*ValidationError); !ok || !reflect.DeepEqual(validationErr.Fields[0].Param("allowed"), []string{"a", "b"}) {
+		t.Errorf("expected allowed strings in error parameters but got: %v", err)
+	}
+
+	_, err = Int().OneOf(1, 2).Validate("", 3.0)
+	if validationErr, ok := err.(*ValidationError); !ok || !reflect.DeepEqual(validationErr.Fields[0].Param("allowed"), []int{1, 2}) {
+		t.Errorf("expected allowed integers in error parameters but got: %v", err)
+	}
+}
@@ -11,6 +11,25 @@ type ValueError struct {
 
 	// Humanly readable error message.
 	Message string `json:"message"`
+
+	// Parameters of the error, such as the allowed values.
+	//
+	// Held by pointer, so that value errors remain comparable.
+	Params *ErrorParams `json:"params,omitempty"`
+}
+
+// Error parameters.
+type ErrorParams map[string]interface{}
+
+// Parameter of the error.
+//
+// Returns nil if the error has no such parameter.
+func (e ValueError) Param(name string) interface{} {
+	if e.Params == nil {
+		return nil
+	}
+
+	return (*e.Params)[name]
 }
 
 var (
@@ -65,3 +84,32 @@ func ValidationErrorAtPath(path Path, valueError ValueError) *ValidationError {
 		Fields: []FieldError{{path, valueError}},
 	}
 }
+
+// Concatenate validation errors.
+//
+// Either error may be nil.
+func concatValidationError(err, o *ValidationError) *ValidationError {
+	if err == nil {
+		return o
+	} else if o == nil {
+		return err
+	}
+
+	return err.Concat(o)
+}
+
+// Prefix validation error.
+//
+// Returns the validation error with all paths joined to the prefix.
+func prefixValidationError(prefix Path, err *ValidationError) *ValidationError {
+	fields := make([]FieldError, len(err.Fields))
+
+	for i, field := range err.Fields {
+		fields[i] = field
+		fields[i].Path = prefix.Join(field.Path)
+	}
+
+	return &ValidationError{
+		Fields: fields,
+	}
+}
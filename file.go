@@ -0,0 +1,200 @@
+package jsonvalid
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Uploaded file.
+//
+// A file part of a multipart form, kept either in memory or in a temporary
+// file on disk.
+type UploadedFile struct {
+	// Name of the file as provided by the client.
+	Filename string
+
+	// Content type as declared by the client.
+	DeclaredContentType string
+
+	// Content type as sniffed from the content of the file.
+	ContentType string
+
+	// Size of the file in bytes.
+	Size int64
+
+	// Header of the part.
+	Header textproto.MIMEHeader
+
+	// Content of the file, if kept in memory.
+	Data []byte
+
+	// Path of the temporary file, if streamed to disk.
+	TempPath string
+}
+
+// Open the file for reading.
+func (f *UploadedFile) Open() (io.ReadCloser, error) {
+	if f.TempPath != "" {
+		return os.Open(f.TempPath)
+	}
+
+	return io.NopCloser(bytes.NewReader(f.Data)), nil
+}
+
+// Remove the file.
+//
+// Removes any temporary file backing the file.
+func (f *UploadedFile) Remove() error {
+	if f.TempPath == "" {
+		return nil
+	}
+
+	err := os.Remove(f.TempPath)
+	f.TempPath = ""
+	return err
+}
+
+type FileValueValidator func(value *UploadedFile) *ValueError
+
+// File validator.
+//
+// Validates files of multipart forms. To limit the number of files in a
+// field, use ArrayOf(File()).MaxLen(n).
+type FileValidator struct {
+	required bool
+	valueValidators []FileValueValidator
+}
+
+func (v *FileValidator) clone() *FileValidator {
+	return &FileValidator{
+		required: v.required,
+		valueValidators: v.valueValidators,
+	}
+}
+
+func (v *FileValidator) Required() *FileValidator {
+	nv := v.clone()
+	nv.required = true
+	return nv
+}
+
+func (v *FileValidator) ValidateValue(fvv FileValueValidator) *FileValidator {
+	nv := v.clone()
+	nv.valueValidators = make([]FileValueValidator, 0, len(v.valueValidators) + 1)
+	nv.valueValidators = append(nv.valueValidators, v.valueValidators...)
+	nv.valueValidators = append(nv.valueValidators, fvv)
+	return nv
+}
+
+func (v *FileValidator) MaxSize(maxSize int64) *FileValidator {
+	return v.ValidateValue(func(value *UploadedFile) *ValueError {
+		if value.Size > maxSize {
+			return &ValueError{
+				Code: "file_too_large",
+				Message: fmt.Sprintf("File must be at most %d bytes", maxSize),
+			}
+		}
+
+		return nil
+	})
+}
+
+// Content types.
+//
+// Allows only the given content types, as sniffed from the content of the
+// file. Types may use a wildcard subtype, such as image/*.
+func (v *FileValidator) ContentTypes(contentTypes ...string) *FileValidator {
+	return v.ValidateValue(func(value *UploadedFile) *ValueError {
+		mediaType, _, _ := mime.ParseMediaType(value.ContentType)
+
+		for _, contentType := range contentTypes {
+			if contentType == mediaType || (strings.HasSuffix(contentType, "/*") && strings.HasPrefix(mediaType, contentType[:len(contentType) - 1])) {
+				return nil
+			}
+		}
+
+		return &ValueError{
+			Code: "invalid_content_type",
+			Message: "File type is not allowed",
+		}
+	})
+}
+
+// Extensions.
+//
+// Allows only file names with the given extensions, such as ".png", compared
+// case insensitively.
+func (v *FileValidator) Extensions(extensions ...string) *FileValidator {
+	return v.ValidateValue(func(value *UploadedFile) *ValueError {
+		ext := filepath.Ext(value.Filename)
+
+		for _, allowed := range extensions {
+			if strings.EqualFold(ext, allowed) {
+				return nil
+			}
+		}
+
+		return &ValueError{
+			Code: "invalid_filename",
+			Message: "File name is not allowed",
+		}
+	})
+}
+
+func (v *FileValidator) FilenameMatches(expr string) *FileValidator {
+	return v.FilenameMatchesRegexp(regexp.MustCompile(expr))
+}
+
+func (v *FileValidator) FilenameMatchesRegexp(re *regexp.Regexp) *FileValidator {
+	return v.ValidateValue(func(value *UploadedFile) *ValueError {
+		if !re.MatchString(value.Filename) {
+			return &ValueError{
+				Code: "invalid_filename",
+				Message: "File name is not allowed",
+			}
+		}
+
+		return nil
+	})
+}
+
+func (v *FileValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	// Test if the value is nil, in which case we can short-circuit to checking
+	// if the value is required.
+	if value == nil {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		return nil, nil
+	}
+
+	// Test if the value is a file.
+	fileValue, ok := value.(*UploadedFile)
+	if !ok {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid_type",
+			Message: "Value must be a file",
+		})
+	}
+
+	// Validate the file.
+	for _, valueValidator := range v.valueValidators {
+		if err := valueValidator(fileValue); err != nil {
+			return nil, ValidationErrorAtPath(path, *err)
+		}
+	}
+
+	return fileValue, nil
+}
+
+func File() *FileValidator {
+	return &FileValidator{}
+}
@@ -3,21 +3,25 @@ package jsonvalid
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 )
 
 type Float64ValueValidator func(value float64) (float64, *ValueError)
 
 type Float64Validator struct {
-	defaultValue float64
 	required bool
+	defaultFunc func() float64
+	coercer Coercer
 	valueValidators []Float64ValueValidator
 }
 
 func (v *Float64Validator) clone() *Float64Validator {
 	return &Float64Validator{
-		defaultValue: v.defaultValue,
 		required: v.required,
+		defaultFunc: v.defaultFunc,
+		coercer: v.coercer,
 		valueValidators: v.valueValidators,
 	}
 }
@@ -29,11 +33,38 @@ func (v *Float64Validator) Required() *Float64Validator {
 }
 
 func (v *Float64Validator) Default(value float64) *Float64Validator {
+	return v.DefaultFunc(func() float64 {
+		return value
+	})
+}
+
+func (v *Float64Validator) DefaultFunc(fn func() float64) *Float64Validator {
 	nv := v.clone()
-	nv.defaultValue = value
+	nv.defaultFunc = fn
 	return nv
 }
 
+func (v *Float64Validator) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
+// Coerce.
+//
+// Sets the coercer used instead of the default coercer.
+func (v *Float64Validator) Coerce(coercer Coercer) *Float64Validator {
+	nv := v.clone()
+	nv.coercer = coercer
+	return nv
+}
+
+func (v *Float64Validator) inheritCoercer(coercer Coercer) Validator {
+	if v.coercer != nil {
+		return v
+	}
+
+	return v.Coerce(coercer)
+}
+
 func (v *Float64Validator) Validate(path Path, value interface{}) (interface{}, error) {
 	// Test if the value is nil, in which case we can short-circuit to checking
 	// if the value is required.
@@ -42,13 +73,17 @@ func (v *Float64Validator) Validate(path Path, value interface{}) (interface{},
 			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
 		}
 
-		return v.defaultValue, nil
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
+		return 0.0, nil
 	}
 
 	// Test if the value is a floating point number.
 	var floatValue float64
 
-	switch tv := value.(type) {
+	switch tv := coerce(v.coercer, CoerceToNumber, value).(type) {
 	case float64:
 		floatValue = tv
 	case json.Number:
@@ -60,14 +95,6 @@ func (v *Float64Validator) Validate(path Path, value interface{}) (interface{},
 				Message: "Value must be a floating point number",
 			})
 		}
-	case string:
-		var err error
-		if floatValue, err = strconv.ParseFloat(tv, 64); err != nil {
-			return nil, ValidationErrorAtPath(path, ValueError{
-				Code: "invalid_type",
-				Message: "Value must be a floating point number",
-			})
-		}
 	default:
 		return nil, ValidationErrorAtPath(path, ValueError{
 			Code: "invalid_type",
@@ -88,6 +115,8 @@ func (v *Float64Validator) Validate(path Path, value interface{}) (interface{},
 
 func (v *Float64Validator) ValidateValue(svv Float64ValueValidator) *Float64Validator {
 	nv := v.clone()
+	nv.valueValidators = make([]Float64ValueValidator, 0, len(v.valueValidators) + 1)
+	nv.valueValidators = append(nv.valueValidators, v.valueValidators...)
 	nv.valueValidators = append(nv.valueValidators, svv)
 	return nv
 }
@@ -97,7 +126,7 @@ func (v *Float64Validator) Min(minValue float64) *Float64Validator {
 		if value < minValue {
 			return value, &ValueError{
 				Code: "invalid",
-				Message: fmt.Sprintf("Value must be at least %d", minValue),
+				Message: fmt.Sprintf("Value must be at least %g", minValue),
 			}
 		}
 
@@ -110,7 +139,134 @@ func (v *Float64Validator) Max(maxValue float64) *Float64Validator {
 		if value > maxValue {
 			return value, &ValueError{
 				Code: "invalid",
-				Message: fmt.Sprintf("Value must be at most %d", maxValue),
+				Message: fmt.Sprintf("Value must be at most %g", maxValue),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func (v *Float64Validator) ExclusiveMin(minValue float64) *Float64Validator {
+	return v.ValidateValue(func(value float64) (float64, *ValueError) {
+		if value <= minValue {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be greater than %g", minValue),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func (v *Float64Validator) ExclusiveMax(maxValue float64) *Float64Validator {
+	return v.ValidateValue(func(value float64) (float64, *ValueError) {
+		if value >= maxValue {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be less than %g", maxValue),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func (v *Float64Validator) Positive() *Float64Validator {
+	return v.ExclusiveMin(0)
+}
+
+func (v *Float64Validator) NonNegative() *Float64Validator {
+	return v.Min(0)
+}
+
+// Multiple of.
+//
+// As floating point division is inexact, the quotient is allowed to deviate
+// slightly from an integer.
+func (v *Float64Validator) MultipleOf(factor float64) *Float64Validator {
+	if factor <= 0 {
+		panic("jsonvalid: factor must be positive")
+	}
+
+	return v.ValidateValue(func(value float64) (float64, *ValueError) {
+		quotient := value / factor
+		tolerance := 1e-9 * math.Max(1, math.Abs(quotient))
+
+		if math.Abs(quotient - math.Round(quotient)) > tolerance {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be a multiple of %g", factor),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+// Finite.
+//
+// Rejects NaN and infinite values.
+func (v *Float64Validator) Finite() *Float64Validator {
+	return v.ValidateValue(func(value float64) (float64, *ValueError) {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: "Value must be a finite number",
+			}
+		}
+
+		return value, nil
+	})
+}
+
+// Maximum decimal places.
+//
+// Limits the number of digits after the decimal point in the shortest
+// representation of the value. Rejects NaN and infinite values.
+func (v *Float64Validator) MaxDecimalPlaces(places int) *Float64Validator {
+	return v.ValidateValue(func(value float64) (float64, *ValueError) {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: "Value must be a finite number",
+			}
+		}
+
+		text := strconv.FormatFloat(value, 'f', -1, 64)
+
+		if i := strings.IndexByte(text, '.'); i >= 0 && len(text) - i - 1 > places {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must have at most %d decimal place(s)", places),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+// Maximum precision.
+//
+// Limits the number of significant digits in the shortest representation of
+// the value. Rejects NaN and infinite values.
+func (v *Float64Validator) MaxPrecision(digits int) *Float64Validator {
+	return v.ValidateValue(func(value float64) (float64, *ValueError) {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: "Value must be a finite number",
+			}
+		}
+
+		text := strconv.FormatFloat(math.Abs(value), 'e', -1, 64)
+		mantissa := strings.Replace(text[:strings.IndexByte(text, 'e')], ".", "", 1)
+
+		if len(mantissa) > digits {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must have at most %d significant digit(s)", digits),
 			}
 		}
 
@@ -0,0 +1,40 @@
+package jsonvalid
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloat64Constraints(t *testing.T) {
+	// Test that rules added to a shared base validator are independent.
+	base := Float64().Finite().Min(0).Max(10)
+	multipleOfThree := base.MultipleOf(3)
+	multipleOfTwo := base.MultipleOf(2)
+
+	if _, err := multipleOfTwo.Validate("", 4.0); err != nil {
+		t.Errorf("unexpected error validating 4 with multiple of 2 validator: %v", err)
+	}
+
+	if _, err := multipleOfThree.Validate("", 4.0); err == nil {
+		t.Errorf("expected error from validating 4 with multiple of 3 validator")
+	}
+
+	// Test that non-finite values are rejected rather than formatted.
+	for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := Float64().MaxPrecision(5).Validate("", value); err == nil {
+			t.Errorf("expected error from validating %v with max precision validator", value)
+		}
+
+		if _, err := Float64().MaxDecimalPlaces(2).Validate("", value); err == nil {
+			t.Errorf("expected error from validating %v with max decimal places validator", value)
+		}
+	}
+
+	if _, err := Float64().MaxPrecision(3).Validate("", 1.234); err == nil {
+		t.Errorf("expected error from validating 1.234 with max precision of 3")
+	}
+
+	if _, err := Float64().MaxDecimalPlaces(2).Validate("", 1.25); err != nil {
+		t.Errorf("unexpected error validating 1.25 with max decimal places of 2: %v", err)
+	}
+}
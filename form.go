@@ -0,0 +1,251 @@
+package jsonvalid
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Parse form values.
+//
+// Converts form values to the generic structure produced by decoding JSON.
+// Keys in bracket notation, such as items[0][name], are converted to nested
+// objects and arrays, and keys ending in [] are appended to arrays. Array
+// indices must be contiguous from zero. Repeated keys are converted to
+// arrays, while single values remain strings.
+func ParseFormValues(values url.Values) (map[string]interface{}, error) {
+	root := &formNode{}
+
+	for key, keyValues := range values {
+		nodeValues := make([]interface{}, len(keyValues))
+		for i, value := range keyValues {
+			nodeValues[i] = value
+		}
+
+		if !root.insertKey(key, nodeValues) {
+			return nil, ErrParseError
+		}
+	}
+
+	obj, ok := root.object()
+	if !ok {
+		return nil, ErrParseError
+	}
+
+	return obj, nil
+}
+
+// Parse and validate form values.
+//
+// Validates form values parsed with ParseFormValues using lenient coercion,
+// unless other coercers have been set on the validators. The paths of any
+// validation errors are in bracket notation to match the form field names,
+// except below custom validators which do not pass on the state of
+// validation to the validators they contain.
+func (v *ObjectValidator) ParseAndValidateValues(values url.Values) (interface{}, error) {
+	obj, err := ParseFormValues(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return validateTracking(inheritCoercer(v, LenientCoercion), "", obj, &validationTracker{formPaths: true})
+}
+
+// Parse and validate HTTP request query string.
+func (v *ObjectValidator) ParseAndValidateHttpQuery(req *http.Request) (interface{}, error) {
+	values, err := url.ParseQuery(req.URL.RawQuery)
+	if err != nil {
+		return nil, ErrParseError
+	}
+
+	return v.ParseAndValidateValues(values)
+}
+
+// Parse and validate HTTP request form body.
+//
+// Validates an application/x-www-form-urlencoded request body.
+func (v *ObjectValidator) ParseAndValidateHttpForm(req *http.Request) (interface{}, error) {
+	if err := req.ParseForm(); err != nil {
+		return nil, ErrParseError
+	}
+
+	return v.ParseAndValidateValues(req.PostForm)
+}
+
+type formNode struct {
+	values []interface{}
+	props map[string]*formNode
+	elems map[int]*formNode
+}
+
+func (n *formNode) insertKey(key string, values []interface{}) bool {
+	segments, ok := parseFormKey(key)
+	if !ok {
+		return false
+	}
+
+	// The name of the key is always a property, even if it is numeric.
+	if n.props == nil {
+		n.props = make(map[string]*formNode)
+	}
+
+	child, ok := n.props[segments[0]]
+	if !ok {
+		child = &formNode{}
+		n.props[segments[0]] = child
+	}
+
+	return child.insert(segments[1:], values)
+}
+
+func (n *formNode) insert(segments []string, values []interface{}) bool {
+	if len(segments) == 0 {
+		if n.props != nil || n.elems != nil {
+			return false
+		}
+
+		n.values = append(n.values, values...)
+		return true
+	}
+
+	if n.values != nil {
+		return false
+	}
+
+	segment := segments[0]
+
+	// Append each value to an array.
+	if segment == "" {
+		if len(segments) > 1 || n.props != nil {
+			return false
+		}
+
+		if n.elems == nil {
+			n.elems = make(map[int]*formNode)
+		}
+
+		next := 0
+		for index := range n.elems {
+			if index >= next {
+				next = index + 1
+			}
+		}
+
+		for i, value := range values {
+			n.elems[next + i] = &formNode{values: []interface{}{value}}
+		}
+
+		return true
+	}
+
+	// Insert into an array element.
+	if index, err := strconv.Atoi(segment); err == nil && index >= 0 && n.props == nil {
+		if n.elems == nil {
+			n.elems = make(map[int]*formNode)
+		}
+
+		child, ok := n.elems[index]
+		if !ok {
+			child = &formNode{}
+			n.elems[index] = child
+		}
+
+		return child.insert(segments[1:], values)
+	}
+
+	// Insert into an object property.
+	if n.elems != nil {
+		return false
+	}
+
+	if n.props == nil {
+		n.props = make(map[string]*formNode)
+	}
+
+	child, ok := n.props[segment]
+	if !ok {
+		child = &formNode{}
+		n.props[segment] = child
+	}
+
+	return child.insert(segments[1:], values)
+}
+
+// Value of node.
+//
+// Array indices must be contiguous from zero, so that the paths of
+// validation errors match the form field names.
+func (n *formNode) value() (interface{}, bool) {
+	switch {
+	case n.props != nil:
+		return n.object()
+
+	case n.elems != nil:
+		arr := make([]interface{}, len(n.elems))
+		for index, child := range n.elems {
+			if index >= len(arr) {
+				return nil, false
+			}
+
+			var ok bool
+			if arr[index], ok = child.value(); !ok {
+				return nil, false
+			}
+		}
+		return arr, true
+
+	case len(n.values) == 1:
+		return n.values[0], true
+
+	default:
+		return n.values, true
+	}
+}
+
+func (n *formNode) object() (map[string]interface{}, bool) {
+	obj := make(map[string]interface{}, len(n.props))
+	for name, child := range n.props {
+		value, ok := child.value()
+		if !ok {
+			return nil, false
+		}
+
+		obj[name] = value
+	}
+
+	return obj, true
+}
+
+// Parse form key.
+//
+// Splits a key in bracket notation into its segments.
+func parseFormKey(key string) ([]string, bool) {
+	i := strings.IndexByte(key, '[')
+	if i < 0 {
+		return []string{key}, key != ""
+	}
+
+	if i == 0 {
+		return nil, false
+	}
+
+	segments := []string{key[:i]}
+	rest := key[i:]
+
+	for rest != "" {
+		if rest[0] != '[' {
+			return nil, false
+		}
+
+		end := strings.IndexByte(rest, ']')
+		if end < 0 {
+			return nil, false
+		}
+
+		segments = append(segments, rest[1:end])
+		rest = rest[end+1:]
+	}
+
+	return segments, true
+}
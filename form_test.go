@@ -0,0 +1,80 @@
+package jsonvalid
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestParseAndValidateValues(t *testing.T) {
+	validator := Object(
+		Prop("page", Int()),
+		Prop("tags", ArrayOf(String())),
+		Prop("ids", ArrayOf(Int())),
+		Prop("items", ArrayOf(Object(Prop("name", String()), Prop("qty", Int())))),
+		Prop("active", Bool()),
+	)
+
+	values, _ := url.ParseQuery("page=2&tags=a&tags=b&ids[]=1&items[0][name]=a&items[1][name]=b&items[1][qty]=3&active=yes")
+
+	result, err := validator.ParseAndValidateValues(values)
+	if err != nil {
+		t.Fatalf("unexpected error validating form values: %v", err)
+	}
+
+	obj := result.(map[string]interface{})
+	if obj["page"] != 2 || obj["active"] != true || len(obj["tags"].([]interface{})) != 2 || len(obj["ids"].([]interface{})) != 1 {
+		t.Errorf("unexpected result from validating form values: %v", obj)
+	}
+
+	items := obj["items"].([]interface{})
+	if len(items) != 2 || items[1].(map[string]interface{})["qty"] != 3 {
+		t.Errorf("unexpected items from validating form values: %v", items)
+	}
+
+	// Test that error paths match the form field names.
+	values, _ = url.ParseQuery("items[0][qty]=x")
+
+	_, err = validator.ParseAndValidateValues(values)
+	if validationErr, ok := err.(*ValidationError); !ok || len(validationErr.Fields) != 1 || validationErr.Fields[0].Path != "items[0][qty]" {
+		t.Errorf("expected error at items[0][qty] but got: %v", err)
+	}
+
+	// Test sparse array indices.
+	values, _ = url.ParseQuery("items[5][qty]=x")
+
+	if _, err = validator.ParseAndValidateValues(values); err != ErrParseError {
+		t.Errorf("expected parse error from sparse array indices but got: %v", err)
+	}
+
+	values, _ = url.ParseQuery("items[1][name]=b&items[0][name]=a")
+
+	if _, err = validator.ParseAndValidateValues(values); err != nil {
+		t.Errorf("unexpected error validating unordered array indices: %v", err)
+	}
+
+	// Test conflicting keys.
+	values, _ = url.ParseQuery("items=a&items[0][name]=b")
+
+	if _, err = validator.ParseAndValidateValues(values); err != ErrParseError {
+		t.Errorf("expected parse error from conflicting keys but got: %v", err)
+	}
+
+	// Test that error paths keep property names containing dots intact.
+	dotted := Object(
+		Prop("user.email", String().Required()),
+		Prop("user", Object(Prop("name", String().Required()))),
+		Prop("items", ArrayOf(Object(Prop("sku.id", Int())))),
+	)
+
+	values, _ = url.ParseQuery("user[name]=Jane&items[0][sku.id]=x")
+
+	_, err = dotted.ParseAndValidateValues(values)
+	AssertFieldError(t, err, "user.email", "required")
+	AssertFieldError(t, err, "items[0][sku.id]", "invalid_type")
+
+	values, _ = url.ParseQuery("user.email=jane@example.com&user[other]=x")
+
+	_, err = dotted.ParseAndValidateValues(values)
+	AssertFieldError(t, err, "user[name]", "required")
+	AssertFieldError(t, err, "user[other]", "invalid_property")
+}
@@ -0,0 +1,381 @@
+package jsonvalid
+
+import (
+	"encoding/hex"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// UUID.
+type UUID [16]byte
+
+// Version of the UUID.
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// String representation of the UUID.
+//
+// Returns the canonical, lower case representation.
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// Format parser.
+//
+// Parses a string in a format, returning the typed value and the normalized
+// string, or false if the string is not valid.
+type formatParser func(value string) (typed interface{}, normalized string, ok bool)
+
+// Format validator.
+//
+// Validates strings in a specific format. The result is the normalized
+// string, or the parsed typed value if Typed is used.
+type FormatValidator struct {
+	required bool
+	defaultFunc func() interface{}
+	typed bool
+	parse formatParser
+	valueError ValueError
+}
+
+func (v *FormatValidator) clone() *FormatValidator {
+	return &FormatValidator{
+		required: v.required,
+		defaultFunc: v.defaultFunc,
+		typed: v.typed,
+		parse: v.parse,
+		valueError: v.valueError,
+	}
+}
+
+func (v *FormatValidator) Required() *FormatValidator {
+	nv := v.clone()
+	nv.required = true
+	return nv
+}
+
+func (v *FormatValidator) Default(value interface{}) *FormatValidator {
+	return v.DefaultFunc(func() interface{} {
+		return value
+	})
+}
+
+func (v *FormatValidator) DefaultFunc(fn func() interface{}) *FormatValidator {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+func (v *FormatValidator) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
+// Typed.
+//
+// Returns the parsed typed value instead of the normalized string.
+func (v *FormatValidator) Typed() *FormatValidator {
+	nv := v.clone()
+	nv.typed = true
+	return nv
+}
+
+func (v *FormatValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	// Test if the value is nil, in which case we can short-circuit to checking
+	// if the value is required.
+	if value == nil {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
+		return nil, nil
+	}
+
+	// Test if the value is a string.
+	strValue, ok := value.(string)
+	if !ok {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid_type",
+			Message: "Value must be a string",
+		})
+	}
+
+	if strValue == "" {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		return nil, nil
+	}
+
+	// Parse the value.
+	typed, normalized, ok := v.parse(strValue)
+	if !ok {
+		return nil, ValidationErrorAtPath(path, v.valueError)
+	}
+
+	if v.typed {
+		return typed, nil
+	}
+
+	return normalized, nil
+}
+
+func newFormatValidator(parse formatParser, code, message string) *FormatValidator {
+	return &FormatValidator{
+		parse: parse,
+		valueError: ValueError{
+			Code: code,
+			Message: message,
+		},
+	}
+}
+
+// Email address.
+//
+// Validates an RFC 5322 addr-spec with an ASCII domain. The typed value is a
+// *mail.Address.
+func Email() *FormatValidator {
+	return newFormatValidator(func(value string) (interface{}, string, bool) {
+		return parseEmail(value, false)
+	}, "invalid_email", "Value must be an email address")
+}
+
+// Internationalized email address.
+//
+// Validates an email address like Email, but also accepts internationalized
+// domain names.
+func IDNEmail() *FormatValidator {
+	return newFormatValidator(func(value string) (interface{}, string, bool) {
+		return parseEmail(value, true)
+	}, "invalid_email", "Value must be an email address")
+}
+
+// Absolute URL.
+//
+// Validates an absolute URL with a host. If schemes are given, the scheme of
+// the URL must be one of them. The typed value is a *url.URL.
+func URL(schemes ...string) *FormatValidator {
+	return newFormatValidator(func(value string) (interface{}, string, bool) {
+		u, err := url.Parse(value)
+		if err != nil || !u.IsAbs() || u.Host == "" {
+			return nil, "", false
+		}
+
+		if len(schemes) > 0 {
+			allowed := false
+			for _, scheme := range schemes {
+				if strings.EqualFold(u.Scheme, scheme) {
+					allowed = true
+					break
+				}
+			}
+
+			if !allowed {
+				return nil, "", false
+			}
+		}
+
+		return u, u.String(), true
+	}, "invalid_url", "Value must be a URL")
+}
+
+// UUID.
+//
+// Validates a UUID in its canonical representation. If versions are given,
+// the version of the UUID must be one of them. The typed value is a UUID.
+func UUIDFormat(versions ...int) *FormatValidator {
+	return newFormatValidator(func(value string) (interface{}, string, bool) {
+		u, ok := parseUUID(value)
+		if !ok {
+			return nil, "", false
+		}
+
+		if len(versions) > 0 {
+			allowed := false
+			for _, version := range versions {
+				if u.Version() == version {
+					allowed = true
+					break
+				}
+			}
+
+			if !allowed {
+				return nil, "", false
+			}
+		}
+
+		return u, u.String(), true
+	}, "invalid_uuid", "Value must be a UUID")
+}
+
+// IP address.
+//
+// Validates an IPv4 or IPv6 address. IPv6 addresses with a zone, such as
+// fe80::1%eth0, are rejected, as zones are only meaningful on the host they
+// refer to. The typed value is a netip.Addr.
+func IP() *FormatValidator {
+	return newFormatValidator(func(value string) (interface{}, string, bool) {
+		addr, err := netip.ParseAddr(value)
+		if err != nil || addr.Zone() != "" {
+			return nil, "", false
+		}
+
+		return addr, addr.String(), true
+	}, "invalid_ip", "Value must be an IP address")
+}
+
+// IPv4 address.
+//
+// The typed value is a netip.Addr.
+func IPv4() *FormatValidator {
+	return newFormatValidator(func(value string) (interface{}, string, bool) {
+		addr, err := netip.ParseAddr(value)
+		if err != nil || !addr.Is4() {
+			return nil, "", false
+		}
+
+		return addr, addr.String(), true
+	}, "invalid_ipv4", "Value must be an IPv4 address")
+}
+
+// IPv6 address.
+//
+// Addresses with a zone are rejected. The typed value is a netip.Addr.
+func IPv6() *FormatValidator {
+	return newFormatValidator(func(value string) (interface{}, string, bool) {
+		addr, err := netip.ParseAddr(value)
+		if err != nil || !addr.Is6() || addr.Zone() != "" {
+			return nil, "", false
+		}
+
+		return addr, addr.String(), true
+	}, "invalid_ipv6", "Value must be an IPv6 address")
+}
+
+// CIDR.
+//
+// Validates an IP prefix in CIDR notation. The typed value is a
+// netip.Prefix.
+func CIDR() *FormatValidator {
+	return newFormatValidator(func(value string) (interface{}, string, bool) {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, "", false
+		}
+
+		return prefix, prefix.String(), true
+	}, "invalid_cidr", "Value must be an IP prefix in CIDR notation")
+}
+
+// Hostname.
+//
+// Validates an RFC 1123 host name, normalized to lower case. The typed value
+// is the normalized string.
+func Hostname() *FormatValidator {
+	return newFormatValidator(func(value string) (interface{}, string, bool) {
+		if !isHostname(value, false) {
+			return nil, "", false
+		}
+
+		normalized := strings.ToLower(strings.TrimSuffix(value, "."))
+		return normalized, normalized, true
+	}, "invalid_hostname", "Value must be a host name")
+}
+
+func parseEmail(value string, idn bool) (interface{}, string, bool) {
+	// Only accept a bare addr-spec, not a name-addr with a display name.
+	if strings.ContainsAny(value, "<>") || strings.TrimSpace(value) != value {
+		return nil, "", false
+	}
+
+	addr, err := mail.ParseAddress(value)
+	if err != nil || addr.Name != "" {
+		return nil, "", false
+	}
+
+	at := strings.LastIndexByte(addr.Address, '@')
+	if at < 0 || !isHostname(addr.Address[at+1:], idn) {
+		return nil, "", false
+	}
+
+	// The local part is only allowed to be non-ASCII for internationalized
+	// addresses.
+	if !idn && !isASCII(addr.Address[:at]) {
+		return nil, "", false
+	}
+
+	return addr, addr.Address, true
+}
+
+func parseUUID(value string) (UUID, bool) {
+	var u UUID
+
+	if len(value) != 36 || value[8] != '-' || value[13] != '-' || value[18] != '-' || value[23] != '-' {
+		return u, false
+	}
+
+	hexValue := value[0:8] + value[9:13] + value[14:18] + value[19:23] + value[24:]
+	if _, err := hex.Decode(u[:], []byte(hexValue)); err != nil {
+		return u, false
+	}
+
+	return u, true
+}
+
+// Is host name.
+//
+// Tests if a string is an RFC 1123 host name, optionally with
+// internationalized labels.
+func isHostname(value string, idn bool) bool {
+	value = strings.TrimSuffix(value, ".")
+
+	if value == "" || len(value) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(value, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label) - 1] == '-' {
+			return false
+		}
+
+		for _, r := range label {
+			switch {
+			case r == '-', r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			case idn && r >= utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)):
+			default:
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func isASCII(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
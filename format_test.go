@@ -0,0 +1,136 @@
+package jsonvalid
+
+import (
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"testing"
+)
+
+func TestFormats(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		validator *FormatValidator
+		code string
+		accepted map[string]string
+		rejected []string
+	}{
+		{
+			"email",
+			Email(),
+			"invalid_email",
+			map[string]string{
+				"jane@example.com": "jane@example.com",
+				"jane.doe+tag@sub.example.org": "jane.doe+tag@sub.example.org",
+			},
+			[]string{"jane", "jane@", "@example.com", "Jane <jane@example.com>", " jane@example.com", "jane@exa_mple.com", "jane@bücher.de", "jäne@example.com"},
+		},
+		{
+			"internationalized email",
+			IDNEmail(),
+			"invalid_email",
+			map[string]string{
+				"jane@bücher.de": "jane@bücher.de",
+				"jäne@example.com": "jäne@example.com",
+			},
+			[]string{"jane@-bücher.de", "jane@bücher..de"},
+		},
+		{
+			"URL",
+			URL("http", "https"),
+			"invalid_url",
+			map[string]string{
+				"https://example.com/path?q=1": "https://example.com/path?q=1",
+				"HTTP://example.com": "http://example.com",
+			},
+			[]string{"example.com", "/path", "ftp://example.com", "https://", "mailto:jane@example.com"},
+		},
+		{
+			"UUID",
+			UUIDFormat(4),
+			"invalid_uuid",
+			map[string]string{
+				"f47ac10b-58cc-4372-a567-0e02b2c3d479": "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+				"F47AC10B-58CC-4372-A567-0E02B2C3D479": "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			},
+			[]string{"f47ac10b58cc4372a5670e02b2c3d479", "f47ac10b-58cc-1372-a567-0e02b2c3d479", "g47ac10b-58cc-4372-a567-0e02b2c3d479"},
+		},
+		{
+			"IP address",
+			IP(),
+			"invalid_ip",
+			map[string]string{
+				"192.0.2.1": "192.0.2.1",
+				"2001:DB8::1": "2001:db8::1",
+			},
+			[]string{"192.0.2", "192.0.2.256", "fe80::1%eth0", "example.com"},
+		},
+		{
+			"IPv4 address",
+			IPv4(),
+			"invalid_ipv4",
+			map[string]string{"192.0.2.1": "192.0.2.1"},
+			[]string{"2001:db8::1", "::ffff:192.0.2.1", "192.000.2.1"},
+		},
+		{
+			"IPv6 address",
+			IPv6(),
+			"invalid_ipv6",
+			map[string]string{"::ffff:192.0.2.1": "::ffff:192.0.2.1"},
+			[]string{"192.0.2.1", "fe80::1%eth0", "2001:db8::1::2"},
+		},
+		{
+			"CIDR",
+			CIDR(),
+			"invalid_cidr",
+			map[string]string{
+				"192.0.2.0/24": "192.0.2.0/24",
+				"2001:db8::/32": "2001:db8::/32",
+			},
+			[]string{"192.0.2.0", "192.0.2.0/33", "fe80::/10%eth0"},
+		},
+		{
+			"host name",
+			Hostname(),
+			"invalid_hostname",
+			map[string]string{
+				"Example.COM.": "example.com",
+				"a-b.example": "a-b.example",
+			},
+			[]string{"-a.example", "a-.example", "a..example", "a_b.example", "bücher.de"},
+		},
+	} {
+		for value, expected := range c.accepted {
+			if result, err := c.validator.Validate("", value); err != nil || result != expected {
+				t.Errorf("unexpected result from validating %q with %s validator: %v, %v", value, c.desc, result, err)
+			}
+		}
+
+		for _, value := range c.rejected {
+			_, err := c.validator.Validate("", value)
+			AssertFieldError(t, err, "", c.code)
+		}
+	}
+}
+
+func TestFormatsTyped(t *testing.T) {
+	if result, err := Email().Typed().Validate("", "jane@example.com"); err != nil || result.(*mail.Address).Address != "jane@example.com" {
+		t.Errorf("unexpected typed result from validating email: %v, %v", result, err)
+	}
+
+	if result, err := URL().Typed().Validate("", "https://example.com/a"); err != nil || result.(*url.URL).Path != "/a" {
+		t.Errorf("unexpected typed result from validating URL: %v, %v", result, err)
+	}
+
+	if result, err := UUIDFormat().Typed().Validate("", "f47ac10b-58cc-4372-a567-0e02b2c3d479"); err != nil || result.(UUID).Version() != 4 {
+		t.Errorf("unexpected typed result from validating UUID: %v, %v", result, err)
+	}
+
+	if result, err := IP().Typed().Validate("", "192.0.2.1"); err != nil || result != netip.MustParseAddr("192.0.2.1") {
+		t.Errorf("unexpected typed result from validating IP address: %v, %v", result, err)
+	}
+
+	if result, err := CIDR().Typed().Validate("", "192.0.2.0/24"); err != nil || result != netip.MustParsePrefix("192.0.2.0/24") {
+		t.Errorf("unexpected typed result from validating CIDR: %v, %v", result, err)
+	}
+}
@@ -0,0 +1,96 @@
+package jsonvalid
+
+import (
+	"unicode"
+)
+
+// Grapheme cluster count.
+//
+// Counts the user-perceived characters of a string. This is an approximation
+// of the extended grapheme clusters of Unicode Standard Annex #29, rather
+// than a full implementation. It joins combining marks, joiners, variation
+// selectors, emoji modifiers, regional indicator pairs and Hangul jamo
+// sequences, but treats all symbols as pictographic and does not implement
+// prepend characters or Indic conjuncts.
+func graphemeClusterCount(value string) int {
+	count := 0
+	prev := rune(-1)
+	regionalIndicators := 0
+
+	for _, r := range value {
+		joins := false
+
+		switch {
+		case prev < 0:
+		case prev == '\r' && r == '\n':
+			joins = true
+		case isGraphemeExtend(r):
+			joins = prev != '\r' && prev != '\n' && !unicode.IsControl(prev)
+		case prev == '\u200d' && isExtendedPictographic(r):
+			joins = true
+		case isRegionalIndicator(r) && isRegionalIndicator(prev):
+			joins = regionalIndicators % 2 == 1
+		case isHangulLeadingJamo(prev):
+			joins = isHangulLeadingJamo(r) || isHangulVowelJamo(r) || isHangulSyllable(r)
+		case isHangulVowelJamo(prev) || isHangulLVSyllable(prev):
+			joins = isHangulVowelJamo(r) || isHangulTrailingJamo(r)
+		case isHangulTrailingJamo(prev) || isHangulSyllable(prev):
+			joins = isHangulTrailingJamo(r)
+		}
+
+		if isRegionalIndicator(r) {
+			regionalIndicators++
+		} else {
+			regionalIndicators = 0
+		}
+
+		if !joins {
+			count++
+		}
+
+		prev = r
+	}
+
+	return count
+}
+
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == '\u200d' ||
+		(r >= 0xfe00 && r <= 0xfe0f) ||
+		(r >= 0xe0100 && r <= 0xe01ef) ||
+		(r >= 0x1f3fb && r <= 0x1f3ff) ||
+		(r >= 0xe0020 && r <= 0xe007f)
+}
+
+func isExtendedPictographic(r rune) bool {
+	return unicode.Is(unicode.So, r) || (r >= 0x1f000 && r <= 0x1faff)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+func isHangulLeadingJamo(r rune) bool {
+	return r >= 0x1100 && r <= 0x115f
+}
+
+func isHangulVowelJamo(r rune) bool {
+	return r >= 0x1160 && r <= 0x11a7
+}
+
+func isHangulTrailingJamo(r rune) bool {
+	return r >= 0x11a8 && r <= 0x11ff
+}
+
+func isHangulSyllable(r rune) bool {
+	return r >= 0xac00 && r <= 0xd7a3
+}
+
+// Is Hangul LV syllable.
+//
+// Tests if a rune is a precomposed Hangul syllable without a trailing
+// consonant.
+func isHangulLVSyllable(r rune) bool {
+	return isHangulSyllable(r) && (r - 0xac00) % 28 == 0
+}
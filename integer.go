@@ -0,0 +1,372 @@
+package jsonvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Integer type.
+type Integer interface {
+	int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64
+}
+
+type IntegerValueValidator[T Integer] func(value T) (T, *ValueError)
+
+// Integer validator.
+//
+// Validates integers of a specific Go type, rejecting values that are not
+// integral or outside the range of the type.
+type IntegerValidator[T Integer] struct {
+	required bool
+	defaultFunc func() T
+	coercer Coercer
+	valueValidators []IntegerValueValidator[T]
+}
+
+func (v *IntegerValidator[T]) clone() *IntegerValidator[T] {
+	return &IntegerValidator[T]{
+		required: v.required,
+		defaultFunc: v.defaultFunc,
+		coercer: v.coercer,
+		valueValidators: v.valueValidators,
+	}
+}
+
+func (v *IntegerValidator[T]) Required() *IntegerValidator[T] {
+	nv := v.clone()
+	nv.required = true
+	return nv
+}
+
+func (v *IntegerValidator[T]) Default(value T) *IntegerValidator[T] {
+	return v.DefaultFunc(func() T {
+		return value
+	})
+}
+
+func (v *IntegerValidator[T]) DefaultFunc(fn func() T) *IntegerValidator[T] {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+func (v *IntegerValidator[T]) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
+// Coerce.
+//
+// Sets the coercer used instead of the default coercer.
+func (v *IntegerValidator[T]) Coerce(coercer Coercer) *IntegerValidator[T] {
+	nv := v.clone()
+	nv.coercer = coercer
+	return nv
+}
+
+func (v *IntegerValidator[T]) inheritCoercer(coercer Coercer) Validator {
+	if v.coercer != nil {
+		return v
+	}
+
+	return v.Coerce(coercer)
+}
+
+func (v *IntegerValidator[T]) Validate(path Path, value interface{}) (interface{}, error) {
+	// Test if the value is nil, in which case we can short-circuit to checking
+	// if the value is required.
+	if value == nil {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
+		return T(0), nil
+	}
+
+	// Test if the value is an integer in range of the type.
+	intValue, valueErr := parseInteger[T](coerce(v.coercer, CoerceToNumber, value))
+	if valueErr != nil {
+		return nil, ValidationErrorAtPath(path, *valueErr)
+	}
+
+	// Validate the value.
+	for _, valueValidator := range v.valueValidators {
+		var err *ValueError
+		if intValue, err = valueValidator(intValue); err != nil {
+			return intValue, ValidationErrorAtPath(path, *err)
+		}
+	}
+
+	return intValue, nil
+}
+
+func (v *IntegerValidator[T]) ValidateValue(ivv IntegerValueValidator[T]) *IntegerValidator[T] {
+	nv := v.clone()
+	nv.valueValidators = make([]IntegerValueValidator[T], 0, len(v.valueValidators) + 1)
+	nv.valueValidators = append(nv.valueValidators, v.valueValidators...)
+	nv.valueValidators = append(nv.valueValidators, ivv)
+	return nv
+}
+
+func (v *IntegerValidator[T]) OneOf(values ...T) *IntegerValidator[T] {
+	valueSet := make(map[T]struct{}, len(values))
+
+	for _, value := range values {
+		valueSet[value] = struct{}{}
+	}
+
+	return v.ValidateValue(func(value T) (T, *ValueError) {
+		if _, ok := valueSet[value]; !ok {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: "Invalid value",
+				Params: &ErrorParams{
+					"allowed": values,
+				},
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func (v *IntegerValidator[T]) Min(minValue T) *IntegerValidator[T] {
+	return v.ValidateValue(func(value T) (T, *ValueError) {
+		if value < minValue {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be at least %d", minValue),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func (v *IntegerValidator[T]) Max(maxValue T) *IntegerValidator[T] {
+	return v.ValidateValue(func(value T) (T, *ValueError) {
+		if value > maxValue {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be at most %d", maxValue),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func (v *IntegerValidator[T]) ExclusiveMin(minValue T) *IntegerValidator[T] {
+	return v.ValidateValue(func(value T) (T, *ValueError) {
+		if value <= minValue {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be greater than %d", minValue),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func (v *IntegerValidator[T]) ExclusiveMax(maxValue T) *IntegerValidator[T] {
+	return v.ValidateValue(func(value T) (T, *ValueError) {
+		if value >= maxValue {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be less than %d", maxValue),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func (v *IntegerValidator[T]) Positive() *IntegerValidator[T] {
+	return v.ExclusiveMin(0)
+}
+
+func (v *IntegerValidator[T]) NonNegative() *IntegerValidator[T] {
+	return v.Min(0)
+}
+
+func (v *IntegerValidator[T]) MultipleOf(factor T) *IntegerValidator[T] {
+	if factor <= 0 {
+		panic("jsonvalid: factor must be positive")
+	}
+
+	return v.ValidateValue(func(value T) (T, *ValueError) {
+		if value % factor != 0 {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be a multiple of %d", factor),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func Int8() *IntegerValidator[int8] {
+	return &IntegerValidator[int8]{}
+}
+
+func Int16() *IntegerValidator[int16] {
+	return &IntegerValidator[int16]{}
+}
+
+func Int32() *IntegerValidator[int32] {
+	return &IntegerValidator[int32]{}
+}
+
+func Int64() *IntegerValidator[int64] {
+	return &IntegerValidator[int64]{}
+}
+
+func Uint() *IntegerValidator[uint] {
+	return &IntegerValidator[uint]{}
+}
+
+func Uint8() *IntegerValidator[uint8] {
+	return &IntegerValidator[uint8]{}
+}
+
+func Uint16() *IntegerValidator[uint16] {
+	return &IntegerValidator[uint16]{}
+}
+
+func Uint32() *IntegerValidator[uint32] {
+	return &IntegerValidator[uint32]{}
+}
+
+func Uint64() *IntegerValidator[uint64] {
+	return &IntegerValidator[uint64]{}
+}
+
+var (
+	// Numeric string expression.
+	//
+	// Matches decimal numbers with an optional fraction and exponent.
+	numericStringRegexp = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+	// Largest integer which cannot be the result of rounding another integer
+	// to a float64.
+	maxSafeFloat64Integer = float64(1 << 53 - 1)
+
+	// Largest exponent accepted when parsing numeric strings.
+	maxNumericStringExponent = 1000
+)
+
+var (
+	valueErrorNotInteger = ValueError{
+		Code: "invalid_type",
+		Message: "Value must be an integer",
+	}
+
+	valueErrorIntegerPrecisionLoss = ValueError{
+		Code: "invalid",
+		Message: "Value is too large to be represented exactly",
+	}
+)
+
+// Parse exact number.
+//
+// Parses a numeric string exactly, guarding against exponents large enough to
+// make arbitrary-precision arithmetic expensive.
+func parseExactNumber(s string) (*big.Rat, bool) {
+	if !numericStringRegexp.MatchString(s) {
+		return nil, false
+	}
+
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(s[i+1:])
+		if err != nil || exp > maxNumericStringExponent || exp < -maxNumericStringExponent {
+			return nil, false
+		}
+	}
+
+	return new(big.Rat).SetString(strings.TrimPrefix(s, "+"))
+}
+
+// Integer range of type.
+func integerRange[T Integer]() (*big.Int, *big.Int) {
+	var zero T
+	typ := reflect.TypeOf(zero)
+	bits := uint(typ.Bits())
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		max := new(big.Int).Lsh(big.NewInt(1), bits - 1)
+		min := new(big.Int).Neg(max)
+		return min, max.Sub(max, big.NewInt(1))
+	default:
+		max := new(big.Int).Lsh(big.NewInt(1), bits)
+		return big.NewInt(0), max.Sub(max, big.NewInt(1))
+	}
+}
+
+// Parse integer.
+//
+// Parses a JSON value as an integer of the given type, rejecting fractional
+// numbers, numbers that have lost precision as float64 and numbers outside
+// the range of the type.
+func parseInteger[T Integer](value interface{}) (T, *ValueError) {
+	var bigValue *big.Int
+
+	switch tv := value.(type) {
+	case float64:
+		if math.IsNaN(tv) || math.IsInf(tv, 0) || math.Trunc(tv) != tv {
+			return 0, &valueErrorNotInteger
+		}
+
+		if math.Abs(tv) > maxSafeFloat64Integer {
+			return 0, &valueErrorIntegerPrecisionLoss
+		}
+
+		bigValue = big.NewInt(int64(tv))
+	case json.Number:
+		ratValue, ok := parseExactNumber(string(tv))
+		if !ok || !ratValue.IsInt() {
+			return 0, &valueErrorNotInteger
+		}
+
+		bigValue = ratValue.Num()
+	default:
+		// Accept Go integers, which are provided when validating values that
+		// were not decoded from JSON.
+		refValue := reflect.ValueOf(value)
+
+		switch refValue.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			bigValue = big.NewInt(refValue.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			bigValue = new(big.Int).SetUint64(refValue.Uint())
+		default:
+			return 0, &valueErrorNotInteger
+		}
+	}
+
+	// Test that the value is in range of the type.
+	min, max := integerRange[T]()
+	if bigValue.Cmp(min) < 0 || bigValue.Cmp(max) > 0 {
+		return 0, &ValueError{
+			Code: "invalid",
+			Message: fmt.Sprintf("Value must be between %s and %s", min, max),
+		}
+	}
+
+	if bigValue.Sign() < 0 {
+		return T(bigValue.Int64()), nil
+	}
+
+	return T(bigValue.Uint64()), nil
+}
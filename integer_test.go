@@ -0,0 +1,40 @@
+package jsonvalid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIntegerRange(t *testing.T) {
+	accepted := []interface{}{0.0, 255.0, json.Number("255"), json.Number("2.55e2"), "12", 7}
+	for _, value := range accepted {
+		if _, err := Uint8().Validate("", value); err != nil {
+			t.Errorf("unexpected error validating %#v with uint8 validator: %v", value, err)
+		}
+	}
+
+	rejected := []interface{}{-1.0, 256.0, 1.5, json.Number("256"), json.Number("0.5"), "1/2", "0x10", 300}
+	for _, value := range rejected {
+		if _, err := Uint8().Validate("", value); err == nil {
+			t.Errorf("expected error from validating %#v with uint8 validator", value)
+		}
+	}
+
+	// Test the boundaries of 64-bit integers.
+	if result, err := Int64().Validate("", json.Number("-9223372036854775808")); err != nil || result != int64(-9223372036854775808) {
+		t.Errorf("unexpected result from validating minimum int64: %v, %v", result, err)
+	}
+
+	if result, err := Uint64().Validate("", json.Number("18446744073709551615")); err != nil || result != uint64(18446744073709551615) {
+		t.Errorf("unexpected result from validating maximum uint64: %v, %v", result, err)
+	}
+
+	if _, err := Int64().Validate("", json.Number("9223372036854775808")); err == nil {
+		t.Errorf("expected error from validating overflowing int64")
+	}
+
+	// Test that integers beyond 2^53 received as float64 are rejected.
+	if _, err := Int64().Validate("", 9007199254740993.0); err == nil {
+		t.Errorf("expected error from validating imprecise float64 integer")
+	}
+}
@@ -0,0 +1,115 @@
+package jsonvalid
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Default maximum depth of lazy validators.
+const DefaultLazyMaxDepth = 32
+
+// Lazy validator.
+//
+// Resolves its validator on first use, so that validators can refer to
+// themselves or to validators which are defined later, e.g. for tree shaped
+// values. Values nested deeper than the maximum depth of lazy validators are
+// rejected.
+//
+// The depth is counted by the validators of this package, which pass it on to
+// nested validators. Validators which do not, such as custom implementations
+// of Validator wrapping other validators, start the count from zero, so
+// recursion through them is not limited.
+type LazyValidator struct {
+	resolve func() Validator
+	once *sync.Once
+	validator Validator
+	maxDepth int
+}
+
+func (v *LazyValidator) clone() *LazyValidator {
+	return &LazyValidator{
+		resolve: v.resolve,
+		once: &sync.Once{},
+		maxDepth: v.maxDepth,
+	}
+}
+
+// Maximum depth.
+//
+// Sets the maximum number of lazy validators which may be nested when
+// validating a value through this validator, counted from where validation
+// starts.
+func (v *LazyValidator) MaxDepth(maxDepth int) *LazyValidator {
+	nv := v.clone()
+	nv.maxDepth = maxDepth
+	return nv
+}
+
+func (v *LazyValidator) resolved() Validator {
+	v.once.Do(func() {
+		v.validator = v.resolve()
+	})
+
+	return v.validator
+}
+
+func (v *LazyValidator) inheritUnknownPolicy(policy *unknownPolicy) Validator {
+	nv := v.clone()
+	nv.resolve = func() Validator {
+		return inheritUnknownPolicy(v.resolved(), policy)
+	}
+	return nv
+}
+
+func (v *LazyValidator) inheritCoercer(coercer Coercer) Validator {
+	nv := v.clone()
+	nv.resolve = func() Validator {
+		return inheritCoercer(v.resolved(), coercer)
+	}
+	return nv
+}
+
+func (v *LazyValidator) hasDefault() bool {
+	d, ok := v.resolved().(defaulter)
+	return ok && d.hasDefault()
+}
+
+func (v *LazyValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	return v.validateTracking(path, value, &validationTracker{})
+}
+
+func (v *LazyValidator) validateTracking(path Path, value interface{}, tracker *validationTracker) (interface{}, error) {
+	if tracker == nil {
+		tracker = &validationTracker{}
+	}
+
+	if tracker.lazyDepth >= v.maxDepth {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid",
+			Message: fmt.Sprintf("Value must be nested at most %d level(s) deep", v.maxDepth),
+		})
+	}
+
+	nested := *tracker
+	nested.lazyDepth++
+	tracker = &nested
+
+	validator := v.resolved()
+
+	if tv, ok := validator.(trackingValidator); ok {
+		return tv.validateTracking(path, value, tracker)
+	}
+
+	return validator.Validate(path, value)
+}
+
+// Lazy.
+//
+// The function is called once, when the validator is first used.
+func Lazy(resolve func() Validator) *LazyValidator {
+	return &LazyValidator{
+		resolve: resolve,
+		once: &sync.Once{},
+		maxDepth: DefaultLazyMaxDepth,
+	}
+}
@@ -0,0 +1,63 @@
+package jsonvalid
+
+import (
+	"strings"
+	"testing"
+)
+
+type lazyTestWrapper struct {
+	validator Validator
+}
+
+func (v lazyTestWrapper) Validate(path Path, value interface{}) (interface{}, error) {
+	return v.validator.Validate(path, value)
+}
+
+func TestLazyDepth(t *testing.T) {
+	// Test that the depth is counted from where validation starts, rather
+	// than from the path.
+	path := Path(strings.Repeat("a.", 39) + "a")
+
+	if _, err := Lazy(func() Validator { return Int() }).MaxDepth(1).Validate(path, 1.0); err != nil {
+		t.Errorf("unexpected error validating lazy validator at deep path: %v", err)
+	}
+
+	if _, err := Object(Prop("a.b.c", Lazy(func() Validator { return Int() }).MaxDepth(1))).Validate("", map[string]interface{}{"a.b.c": 1.0}); err != nil {
+		t.Errorf("unexpected error validating lazy validator of property with dots: %v", err)
+	}
+
+	// Test that only nested lazy validators are counted.
+	var node *ObjectValidator
+	node = Object(Prop("child", Lazy(func() Validator {
+		return node
+	}).MaxDepth(3)))
+
+	// Missing properties are validated through the lazy validator as well,
+	// so the innermost object counts as a level.
+	value := map[string]interface{}{}
+	for i := 0; i < 2; i++ {
+		value = map[string]interface{}{"child": value}
+	}
+
+	if _, err := node.Validate("", value); err != nil {
+		t.Errorf("unexpected error validating value nested as deep as maximum depth: %v", err)
+	}
+
+	AssertObjectValidationFails(t, "value nested deeper than maximum depth", "recursive object validator", node, map[string]interface{}{"child": value})
+
+	// Test that recursion through validators which do not pass the depth on
+	// is not limited.
+	var wrapped Validator
+	wrapped = Object(Prop("child", lazyTestWrapper{Lazy(func() Validator {
+		return wrapped
+	})}))
+
+	value = map[string]interface{}{}
+	for i := 0; i < 2 * DefaultLazyMaxDepth; i++ {
+		value = map[string]interface{}{"child": value}
+	}
+
+	if _, err := wrapped.Validate("", value); err != nil {
+		t.Errorf("unexpected error validating value nested through wrapper: %v", err)
+	}
+}
@@ -0,0 +1,257 @@
+package jsonvalid
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+)
+
+// Multipart options.
+//
+// Limits applied while streaming a multipart form. Zero values select the
+// defaults.
+type MultipartOptions struct {
+	// Maximum number of bytes of files kept in memory before files are
+	// streamed to disk. Defaults to 32 MB.
+	MaxMemory int64
+
+	// Maximum size of a single file. Larger files are rejected without being
+	// read entirely. Defaults to 32 MB.
+	MaxFileSize int64
+
+	// Maximum total size of all files. Forms with larger files are rejected.
+	// Defaults to 256 MB.
+	MaxTotalFileSize int64
+
+	// Maximum size of a text field. Defaults to 1 MB.
+	MaxFieldSize int64
+
+	// Maximum number of parts. Defaults to 1000.
+	MaxParts int
+
+	// Directory for temporary files. Defaults to os.TempDir().
+	TempDir string
+}
+
+const (
+	defaultMultipartMaxMemory = 32 << 20
+	defaultMultipartMaxFileSize = 32 << 20
+	defaultMultipartMaxTotalFileSize = 256 << 20
+	defaultMultipartMaxFieldSize = 1 << 20
+	defaultMultipartMaxParts = 1000
+)
+
+// Parse and validate HTTP request multipart form.
+//
+// Validates a multipart/form-data request body with text fields and files.
+// Fields are parsed as with ParseFormValues, with file parts given as
+// *UploadedFile values to be validated with File validators.
+//
+// All uploaded files are returned, including files of properties left out of
+// the result, and must be removed by the caller with RemoveUploadedFiles.
+// If the form is invalid, the files are removed before returning.
+func (v *ObjectValidator) ParseAndValidateHttpMultipart(req *http.Request, opts MultipartOptions) (interface{}, []*UploadedFile, error) {
+	reader, err := req.MultipartReader()
+	if err != nil {
+		return nil, nil, ErrParseError
+	}
+
+	root, files, streamErr, err := parseMultipart(reader, opts)
+	if err != nil {
+		RemoveUploadedFiles(files)
+		return nil, nil, err
+	}
+
+	obj, ok := root.object()
+	if !ok {
+		RemoveUploadedFiles(files)
+		return nil, nil, ErrParseError
+	}
+
+	// Validate the form.
+	result, err := validateTracking(inheritCoercer(v, LenientCoercion), "", obj, &validationTracker{formPaths: true})
+	if validationErr, ok := err.(*ValidationError); ok {
+		err = concatValidationError(streamErr, validationErr)
+	} else if err == nil && streamErr != nil {
+		err = streamErr
+	}
+
+	if err != nil {
+		RemoveUploadedFiles(files)
+		return nil, nil, err
+	}
+
+	return result, files, nil
+}
+
+// Remove uploaded files.
+//
+// Removes the temporary files backing the uploaded files.
+func RemoveUploadedFiles(files []*UploadedFile) {
+	for _, file := range files {
+		file.Remove()
+	}
+}
+
+func parseMultipart(reader *multipart.Reader, opts MultipartOptions) (*formNode, []*UploadedFile, *ValidationError, error) {
+	if opts.MaxMemory <= 0 {
+		opts.MaxMemory = defaultMultipartMaxMemory
+	}
+
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = defaultMultipartMaxFileSize
+	}
+
+	if opts.MaxTotalFileSize <= 0 {
+		opts.MaxTotalFileSize = defaultMultipartMaxTotalFileSize
+	}
+
+	if opts.MaxFieldSize <= 0 {
+		opts.MaxFieldSize = defaultMultipartMaxFieldSize
+	}
+
+	if opts.MaxParts <= 0 {
+		opts.MaxParts = defaultMultipartMaxParts
+	}
+
+	root := &formNode{}
+	var files []*UploadedFile
+	var streamErr *ValidationError
+	memoryLeft := opts.MaxMemory
+	totalFileSize := int64(0)
+
+	for parts := 0; ; parts++ {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil || parts >= opts.MaxParts {
+			return nil, files, nil, ErrParseError
+		}
+
+		name := part.FormName()
+		if name == "" {
+			part.Close()
+			continue
+		}
+
+		// Read text fields.
+		if part.FileName() == "" {
+			data, err := io.ReadAll(io.LimitReader(part, opts.MaxFieldSize + 1))
+			part.Close()
+
+			if err != nil || int64(len(data)) > opts.MaxFieldSize {
+				return nil, files, nil, ErrParseError
+			}
+
+			if !root.insertKey(name, []interface{}{string(data)}) {
+				return nil, files, nil, ErrParseError
+			}
+
+			continue
+		}
+
+		// Reject malformed names before reading the file.
+		if _, ok := parseFormKey(name); !ok {
+			part.Close()
+			return nil, files, nil, ErrParseError
+		}
+
+		// Stream files to memory or disk.
+		file, tooLarge, err := readUploadedFile(part, opts, &memoryLeft)
+		part.Close()
+
+		if file != nil {
+			files = append(files, file)
+		}
+
+		if err != nil {
+			return nil, files, nil, err
+		}
+
+		if file != nil {
+			if totalFileSize += file.Size; totalFileSize > opts.MaxTotalFileSize {
+				return nil, files, nil, ErrParseError
+			}
+		}
+
+		// The paths of errors of files which are too large are the names of
+		// the form fields as submitted.
+		if tooLarge {
+			streamErr = concatValidationError(streamErr, ValidationErrorAtPath(Path(name), ValueError{
+				Code: "file_too_large",
+				Message: fmt.Sprintf("File must be at most %d bytes", opts.MaxFileSize),
+			}))
+			continue
+		}
+
+		if !root.insertKey(name, []interface{}{file}) {
+			return nil, files, nil, ErrParseError
+		}
+	}
+
+	return root, files, streamErr, nil
+}
+
+func readUploadedFile(part *multipart.Part, opts MultipartOptions, memoryLeft *int64) (*UploadedFile, bool, error) {
+	file := &UploadedFile{
+		Filename: part.FileName(),
+		DeclaredContentType: part.Header.Get("Content-Type"),
+		Header: part.Header,
+	}
+
+	src := io.LimitReader(part, opts.MaxFileSize + 1)
+
+	// Read into memory as far as allowed.
+	var buf bytes.Buffer
+	n, err := io.CopyN(&buf, src, *memoryLeft + 1)
+	if err != nil && err != io.EOF {
+		return nil, false, ErrParseError
+	}
+
+	if n <= *memoryLeft {
+		*memoryLeft -= n
+		file.Data = buf.Bytes()
+		file.Size = n
+	} else {
+		// Spill to disk.
+		tmpFile, err := os.CreateTemp(opts.TempDir, "jsonvalid-upload-")
+		if err != nil {
+			return nil, false, err
+		}
+
+		file.TempPath = tmpFile.Name()
+
+		size, err := io.Copy(tmpFile, io.MultiReader(&buf, src))
+		if closeErr := tmpFile.Close(); err == nil {
+			err = closeErr
+		}
+
+		if err != nil {
+			return file, false, ErrParseError
+		}
+
+		file.Size = size
+	}
+
+	if file.Size > opts.MaxFileSize {
+		*memoryLeft += int64(len(file.Data))
+		file.Remove()
+		return nil, true, nil
+	}
+
+	// Sniff the content type.
+	reader, err := file.Open()
+	if err != nil {
+		return file, false, err
+	}
+	defer reader.Close()
+
+	head := make([]byte, 512)
+	headLen, _ := io.ReadFull(reader, head)
+	file.ContentType = http.DetectContentType(head[:headLen])
+
+	return file, false, nil
+}
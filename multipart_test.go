@@ -0,0 +1,174 @@
+package jsonvalid
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"testing"
+)
+
+var multipartTestPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00")
+
+type multipartTestFile struct {
+	field string
+	filename string
+	contentType string
+	data []byte
+}
+
+func newMultipartTestRequest(t *testing.T, fields map[string]string, files ...multipartTestFile) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	for name, value := range fields {
+		writer.WriteField(name, value)
+	}
+
+	for _, file := range files {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", `form-data; name="` + file.field + `"; filename="` + file.filename + `"`)
+		header.Set("Content-Type", file.contentType)
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			t.Fatalf("unexpected error creating multipart part: %v", err)
+		}
+
+		part.Write(file.data)
+	}
+
+	writer.Close()
+
+	req, _ := http.NewRequest("POST", "/", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func AssertTempDirEmpty(t *testing.T, dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error reading temporary directory: %v", err)
+	}
+
+	if len(entries) != 0 {
+		t.Errorf("expected temporary directory to be empty but it contains %d file(s)", len(entries))
+	}
+}
+
+func AssertFieldError(t *testing.T, err error, path Path, code string) {
+	if validationErr, ok := err.(*ValidationError); ok {
+		for _, field := range validationErr.Fields {
+			if field.Path == path && field.Code == code {
+				return
+			}
+		}
+	}
+
+	t.Errorf("expected %s error at %s but got: %v", code, path, err)
+}
+
+func TestParseAndValidateHttpMultipart(t *testing.T) {
+	validator := Object(
+		Prop("title", String().Required()),
+		Prop("avatar", File().Required().ContentTypes("image/png").Extensions(".png")),
+		Prop("attachments", ArrayOf(File()).MaxLen(2)),
+	)
+	tempDir := t.TempDir()
+	opts := MultipartOptions{MaxMemory: 16, TempDir: tempDir}
+
+	// Test parsing fields and files, with files beyond the memory limit
+	// streamed to disk.
+	req := newMultipartTestRequest(t, map[string]string{"title": "Profile"},
+		multipartTestFile{"avatar", "avatar.png", "application/octet-stream", multipartTestPNG},
+		multipartTestFile{"attachments[]", "a.txt", "text/plain", []byte("a")},
+		multipartTestFile{"attachments[]", "b.txt", "text/plain", []byte("b")},
+	)
+
+	result, files, err := validator.ParseAndValidateHttpMultipart(req, opts)
+	if err != nil {
+		t.Fatalf("unexpected error validating multipart form: %v", err)
+	}
+
+	obj := result.(map[string]interface{})
+	avatar := obj["avatar"].(*UploadedFile)
+
+	if obj["title"] != "Profile" || len(obj["attachments"].([]interface{})) != 2 || len(files) != 3 {
+		t.Errorf("unexpected result from validating multipart form: %v", obj)
+	}
+
+	if avatar.ContentType != "image/png" || avatar.DeclaredContentType != "application/octet-stream" || avatar.Size != int64(len(multipartTestPNG)) {
+		t.Errorf("unexpected uploaded file: %+v", avatar)
+	}
+
+	if avatar.TempPath == "" {
+		t.Errorf("expected file beyond memory limit to be streamed to disk")
+	} else if reader, err := avatar.Open(); err != nil {
+		t.Errorf("unexpected error opening uploaded file: %v", err)
+	} else {
+		data, _ := io.ReadAll(reader)
+		reader.Close()
+
+		if !bytes.Equal(data, multipartTestPNG) {
+			t.Errorf("unexpected content of uploaded file: %q", data)
+		}
+	}
+
+	if attachment := obj["attachments"].([]interface{})[0].(*UploadedFile); attachment.TempPath != "" || string(attachment.Data) != "a" {
+		t.Errorf("expected small file to be kept in memory: %+v", attachment)
+	}
+
+	RemoveUploadedFiles(files)
+	AssertTempDirEmpty(t, tempDir)
+
+	// Test that sniffed content types are validated and temporary files are
+	// removed on validation errors.
+	req = newMultipartTestRequest(t, map[string]string{"title": "Profile"},
+		multipartTestFile{"avatar", "avatar.png", "image/png", bytes.Repeat([]byte("text "), 10)},
+	)
+
+	_, _, err = validator.ParseAndValidateHttpMultipart(req, opts)
+	if validationErr, ok := err.(*ValidationError); !ok || len(validationErr.Fields) != 1 || validationErr.Fields[0].Code != "invalid_content_type" {
+		t.Errorf("expected invalid content type error but got: %v", err)
+	}
+
+	AssertTempDirEmpty(t, tempDir)
+
+	// Test that files larger than the maximum size are rejected.
+	opts.MaxFileSize = 20
+	req = newMultipartTestRequest(t, map[string]string{"title": "Profile"},
+		multipartTestFile{"avatar", "avatar.png", "image/png", append(multipartTestPNG, make([]byte, 100)...)},
+	)
+
+	_, _, err = validator.ParseAndValidateHttpMultipart(req, opts)
+	AssertFieldError(t, err, "avatar", "file_too_large")
+
+	AssertTempDirEmpty(t, tempDir)
+
+	// Test that files with malformed names are rejected, even if too large.
+	req = newMultipartTestRequest(t, map[string]string{"title": "Profile"},
+		multipartTestFile{"[a]", "avatar.png", "image/png", append(multipartTestPNG, make([]byte, 100)...)},
+	)
+
+	if _, _, err = validator.ParseAndValidateHttpMultipart(req, opts); err != ErrParseError {
+		t.Errorf("expected parse error from file with malformed name but got: %v", err)
+	}
+
+	AssertTempDirEmpty(t, tempDir)
+
+	// Test that forms with too many files in total are rejected.
+	opts.MaxFileSize = 0
+	opts.MaxTotalFileSize = 40
+	req = newMultipartTestRequest(t, map[string]string{"title": "Profile"},
+		multipartTestFile{"avatar", "avatar.png", "image/png", multipartTestPNG},
+		multipartTestFile{"attachments[]", "a.txt", "text/plain", make([]byte, 20)},
+	)
+
+	if _, _, err = validator.ParseAndValidateHttpMultipart(req, opts); err != ErrParseError {
+		t.Errorf("expected parse error from files exceeding total size but got: %v", err)
+	}
+
+	AssertTempDirEmpty(t, tempDir)
+}
@@ -1,23 +1,35 @@
 package jsonvalid
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"io/ioutil"
 	"reflect"
+	"regexp"
 )
 
 type ObjectValidator struct {
 	required bool
+	defaultFunc func() interface{}
+	defaultFromProps bool
 	props map[string]*ObjectProp
+	patternProps []*ObjectPatternProp
 	targetType reflect.Type
+	unknown *unknownPolicy
 }
 
 func (v *ObjectValidator) clone() *ObjectValidator {
 	return &ObjectValidator{
 		required: v.required,
+		defaultFunc: v.defaultFunc,
+		defaultFromProps: v.defaultFromProps,
 		props: v.props,
+		patternProps: v.patternProps,
 		targetType: v.targetType,
+		unknown: v.unknown,
 	}
 }
 
@@ -27,6 +39,32 @@ func (v *ObjectValidator) Required() *ObjectValidator {
 	return nv
 }
 
+func (v *ObjectValidator) Default(value interface{}) *ObjectValidator {
+	return v.DefaultFunc(func() interface{} {
+		return value
+	})
+}
+
+func (v *ObjectValidator) DefaultFunc(fn func() interface{}) *ObjectValidator {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+// Default from properties.
+//
+// Validates an empty object when the value is null or missing, so that the
+// result is an object of the defaults of the properties.
+func (v *ObjectValidator) DefaultFromProps() *ObjectValidator {
+	nv := v.clone()
+	nv.defaultFromProps = true
+	return nv
+}
+
+func (v *ObjectValidator) hasDefault() bool {
+	return v.defaultFunc != nil || v.defaultFromProps
+}
+
 func (v *ObjectValidator) UnmarshalTo(typ interface{}) *ObjectValidator {
 	nv := v.clone()
 
@@ -39,6 +77,228 @@ func (v *ObjectValidator) UnmarshalTo(typ interface{}) *ObjectValidator {
 	return nv
 }
 
+// Extend.
+//
+// Adds properties, replacing existing properties with the same names.
+func (v *ObjectValidator) Extend(props ...*ObjectProp) *ObjectValidator {
+	nv := v.clone()
+	nv.props = make(map[string]*ObjectProp, len(v.props) + len(props))
+
+	for name, prop := range v.props {
+		nv.props[name] = prop
+	}
+
+	for _, prop := range props {
+		nv.props[prop.Name()] = prop
+	}
+
+	return nv
+}
+
+// Pick.
+//
+// Keeps only the properties with the given names. Panics if a property does
+// not exist.
+func (v *ObjectValidator) Pick(names ...string) *ObjectValidator {
+	nv := v.clone()
+	nv.props = make(map[string]*ObjectProp, len(names))
+
+	for _, name := range names {
+		nv.props[name] = v.mustProp(name)
+	}
+
+	return nv
+}
+
+// Omit.
+//
+// Removes the properties with the given names. Panics if a property does not
+// exist.
+func (v *ObjectValidator) Omit(names ...string) *ObjectValidator {
+	nv := v.clone()
+	nv.props = make(map[string]*ObjectProp, len(v.props))
+
+	for name, prop := range v.props {
+		nv.props[name] = prop
+	}
+
+	for _, name := range names {
+		v.mustProp(name)
+		delete(nv.props, name)
+	}
+
+	return nv
+}
+
+// Merge.
+//
+// Adds the properties and pattern properties of another object validator,
+// with its properties replacing existing properties with the same names. The
+// requiredness, default, target type and unknown property policy of the
+// validator are kept.
+func (v *ObjectValidator) Merge(other *ObjectValidator) *ObjectValidator {
+	props := make([]*ObjectProp, 0, len(other.props))
+	for _, prop := range other.props {
+		props = append(props, prop)
+	}
+
+	nv := v.Extend(props...)
+	nv.patternProps = make([]*ObjectPatternProp, 0, len(v.patternProps) + len(other.patternProps))
+	nv.patternProps = append(nv.patternProps, v.patternProps...)
+	nv.patternProps = append(nv.patternProps, other.patternProps...)
+	return nv
+}
+
+// Override property.
+//
+// Replaces the validator of an existing property. Panics if the property
+// does not exist.
+func (v *ObjectValidator) Override(name string, validator Validator) *ObjectValidator {
+	v.mustProp(name)
+	return v.Extend(Prop(name, validator))
+}
+
+// Override property with function.
+//
+// Replaces the validator of an existing property with the result of calling
+// the function with the current validator, e.g. to make it required. Panics
+// if the property does not exist.
+func (v *ObjectValidator) OverrideFunc(name string, fn func(validator Validator) Validator) *ObjectValidator {
+	return v.Extend(Prop(name, fn(v.mustProp(name).Validator())))
+}
+
+func (v *ObjectValidator) mustProp(name string) *ObjectProp {
+	prop, ok := v.props[name]
+	if !ok {
+		panic(fmt.Sprintf("jsonvalid: object has no property %s", name))
+	}
+
+	return prop
+}
+
+// Pattern property.
+//
+// Validates properties whose names match the regular expression and which
+// are not declared with Prop. Exact properties always take precedence over
+// pattern properties, and if several patterns match a name, the first one
+// declared is used.
+func (v *ObjectValidator) PatternProp(expr string, validator Validator) *ObjectValidator {
+	return v.PatternPropRegexp(regexp.MustCompile(expr), validator)
+}
+
+// Pattern property with compiled regular expression.
+func (v *ObjectValidator) PatternPropRegexp(re *regexp.Regexp, validator Validator) *ObjectValidator {
+	nv := v.clone()
+	nv.patternProps = make([]*ObjectPatternProp, len(v.patternProps), len(v.patternProps) + 1)
+	copy(nv.patternProps, v.patternProps)
+	nv.patternProps = append(nv.patternProps, &ObjectPatternProp{
+		pattern: re,
+		validator: validator,
+	})
+	return nv
+}
+
+func (v *ObjectValidator) propValidator(name string) (Validator, bool) {
+	if prop, ok := v.props[name]; ok {
+		return prop.Validator(), true
+	}
+
+	for _, patternProp := range v.patternProps {
+		if patternProp.Pattern().MatchString(name) {
+			return patternProp.Validator(), true
+		}
+	}
+
+	return nil, false
+}
+
+// Reject unknown properties.
+//
+// Unknown properties result in an invalid_property error. This is the
+// default policy.
+func (v *ObjectValidator) RejectUnknown() *ObjectValidator {
+	return v.withUnknownPolicy(&unknownPolicy{mode: UnknownPropertiesReject})
+}
+
+// Strip unknown properties.
+//
+// Unknown properties are silently left out of the result.
+func (v *ObjectValidator) StripUnknown() *ObjectValidator {
+	return v.withUnknownPolicy(&unknownPolicy{mode: UnknownPropertiesStrip})
+}
+
+// Pass through unknown properties.
+//
+// Unknown properties are included in the result without validation.
+func (v *ObjectValidator) PassThroughUnknown() *ObjectValidator {
+	return v.withUnknownPolicy(&unknownPolicy{mode: UnknownPropertiesPassThrough})
+}
+
+// Validate unknown properties.
+//
+// Unknown properties are validated with the catch-all validator and included
+// in the result.
+func (v *ObjectValidator) ValidateUnknown(validator Validator) *ObjectValidator {
+	return v.withUnknownPolicy(&unknownPolicy{
+		mode: UnknownPropertiesValidate,
+		validator: validator,
+	})
+}
+
+// Collect unknown properties.
+//
+// Unknown properties are collected into the map field with the given name on
+// the struct set with UnmarshalTo. Without a target type, unknown properties
+// are passed through.
+func (v *ObjectValidator) CollectUnknown(fieldName string) *ObjectValidator {
+	return v.withUnknownPolicy(&unknownPolicy{
+		mode: UnknownPropertiesCollect,
+		fieldName: fieldName,
+	})
+}
+
+func (v *ObjectValidator) withUnknownPolicy(policy *unknownPolicy) *ObjectValidator {
+	nv := v.clone()
+	nv.unknown = policy
+	return nv
+}
+
+func (v *ObjectValidator) inheritUnknownPolicy(policy *unknownPolicy) Validator {
+	if v.unknown != nil {
+		return v
+	}
+
+	inherited := *policy
+	inherited.inherited = true
+	return v.withUnknownPolicy(&inherited)
+}
+
+func (v *ObjectValidator) inheritCoercer(coercer Coercer) Validator {
+	nv := v.clone()
+	nv.props = make(map[string]*ObjectProp, len(v.props))
+
+	for name, prop := range v.props {
+		nv.props[name] = Prop(name, inheritCoercer(prop.Validator(), coercer))
+	}
+
+	nv.patternProps = make([]*ObjectPatternProp, len(v.patternProps))
+
+	for i, patternProp := range v.patternProps {
+		nv.patternProps[i] = &ObjectPatternProp{
+			pattern: patternProp.Pattern(),
+			validator: inheritCoercer(patternProp.Validator(), coercer),
+		}
+	}
+
+	if v.unknown != nil && v.unknown.validator != nil {
+		policy := *v.unknown
+		policy.validator = inheritCoercer(policy.validator, coercer)
+		nv.unknown = &policy
+	}
+
+	return nv
+}
+
 func (v *ObjectValidator) ParseAndValidateHttpRequest(req *http.Request) (interface{}, error) {
 	// First, read the request body.
 	data, err := ioutil.ReadAll(req.Body)
@@ -48,19 +308,50 @@ func (v *ObjectValidator) ParseAndValidateHttpRequest(req *http.Request) (interf
 
 	// Parse the form as a JSON object.
 	var jsonObj map[string]interface{}
-	if err = json.Unmarshal(data, &jsonObj); err != nil {
+	if err = decodeJSON(data, &jsonObj); err != nil {
 		return nil, ErrParseError
 	}
 
-	return v.Validate("", jsonObj)
+	// Keep the original JSON of the values, for raw validators.
+	return validateTracking(v, "", jsonObj, &validationTracker{rawDocument: &rawJSONDocument{data: data}})
+}
+
+// Decode JSON.
+//
+// Numbers are decoded as json.Number, so that no precision is lost before
+// validation.
+func decodeJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+
+	// Reject trailing data.
+	if _, err := decoder.Token(); err != io.EOF {
+		return ErrParseError
+	}
+
+	return nil
 }
 
 func (v *ObjectValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	return v.validateTracking(path, value, &validationTracker{})
+}
+
+func (v *ObjectValidator) validateTracking(path Path, value interface{}, tracker *validationTracker) (interface{}, error) {
 	if value == nil {
 		if v.required {
 			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
 		}
 
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		} else if v.defaultFromProps {
+			return v.validateTracking(path, map[string]interface{}{}, tracker)
+		}
+
 		return nil, nil
 	}
 
@@ -75,20 +366,45 @@ func (v *ObjectValidator) Validate(path Path, value interface{}) (interface{}, e
 
 	// Validate each provided property.
 	var err *ValidationError
+	var extra map[string]interface{}
 	result := make(map[string]interface{})
 
+	policy := v.unknown
+	if policy == nil {
+		policy = &unknownPolicy{mode: UnknownPropertiesReject}
+	}
+
+	collect := policy.mode == UnknownPropertiesCollect && v.targetType != nil &&
+		(!policy.inherited || hasCollectingField(v.targetType, policy.fieldName))
+	if collect {
+		extra = make(map[string]interface{})
+	}
+
 	for propName, propValue := range jsonObj {
 		var resultValue interface{}
 		var resultErr error
 
-		prop, ok := v.props[propName]
-		if !ok {
-			resultErr = ValidationErrorAtPath(path.Prop(propName), ValueError{
-				Code: "invalid_property",
-				Message: "Invalid property",
-			})
+		propPath := tracker.propPath(path, propName)
+
+		validator, ok := v.propValidator(propName)
+		if ok {
+			validator = inheritUnknownPolicy(validator, v.unknown)
+			resultValue, resultErr = validateTracking(validator, propPath, propValue, tracker.Prop(propName))
 		} else {
-			resultValue, resultErr = prop.Validator().Validate(path.Prop(propName), propValue)
+			switch policy.mode {
+			case UnknownPropertiesStrip:
+				continue
+			case UnknownPropertiesPassThrough, UnknownPropertiesCollect:
+				resultValue = propValue
+			case UnknownPropertiesValidate:
+				validator := inheritUnknownPolicy(policy.validator, v.unknown)
+				resultValue, resultErr = validateTracking(validator, propPath, propValue, tracker.Prop(propName))
+			default:
+				resultErr = ValidationErrorAtPath(propPath, ValueError{
+					Code: "invalid_property",
+					Message: "Invalid property",
+				})
+			}
 		}
 
 		if resultErr != nil {
@@ -103,13 +419,18 @@ func (v *ObjectValidator) Validate(path Path, value interface{}) (interface{}, e
 			}
 		}
 
-		result[propName] = resultValue
+		if !ok && collect {
+			extra[propName] = resultValue
+		} else {
+			result[propName] = resultValue
+		}
 	}
 
 	// Validate all properties not provided in the object.
 	for propName, prop := range v.props {
 		if _, handled := result[propName]; !handled {
-			resultValue, resultErr := prop.Validator().Validate(path.Prop(propName), nil)
+			validator := inheritUnknownPolicy(prop.Validator(), v.unknown)
+			resultValue, resultErr := validateTracking(validator, tracker.propPath(path, propName), nil, tracker.Prop(propName))
 
 			if resultErr != nil {
 				if resultValidationErr, ok := resultErr.(*ValidationError); ok {
@@ -137,6 +458,10 @@ func (v *ObjectValidator) Validate(path Path, value interface{}) (interface{}, e
 		return result, nil
 	}
 
+	if collect {
+		return unmarshalObjectCollecting(result, extra, policy.fieldName, v.targetType)
+	}
+
 	return unmarshalObject(result, v.targetType)
 }
 
@@ -171,3 +496,17 @@ func Prop(name string, validator Validator) *ObjectProp {
 		validator: validator,
 	}
 }
+
+// Object pattern property.
+type ObjectPatternProp struct {
+	pattern *regexp.Regexp
+	validator Validator
+}
+
+func (p *ObjectPatternProp) Pattern() *regexp.Regexp {
+	return p.pattern
+}
+
+func (p *ObjectPatternProp) Validator() Validator {
+	return p.validator
+}
@@ -0,0 +1,368 @@
+package jsonvalid
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type objectTestTarget struct {
+	Name string `json:"name"`
+	Extra map[string]interface{} `json:"-"`
+}
+
+type objectTestChild struct {
+	Name string `json:"name"`
+}
+
+type objectTestParent struct {
+	Name string `json:"name"`
+	Child objectTestChild `json:"child"`
+	Extra map[string]interface{} `json:"-"`
+}
+
+func AssertObjectValidationFails(t *testing.T, valueDesc, validatorDesc string, validator Validator, value interface{}) {
+	_, err := validator.Validate("", value)
+
+	if err == nil {
+		t.Errorf("expected error from validating %s with %s", valueDesc, validatorDesc)
+	}
+}
+
+func AssertObjectValidationResult(t *testing.T, valueDesc, validatorDesc string, validator Validator, value interface{}, expected map[string]interface{}) {
+	result, err := validator.Validate("", value)
+
+	if err != nil {
+		t.Errorf("unexpected error validating %s with %s: %v", valueDesc, validatorDesc, err)
+		return
+	}
+
+	object, ok := result.(map[string]interface{})
+	if !ok {
+		t.Errorf("return value from validating %s with %s is not an object: %v", valueDesc, validatorDesc, result)
+		return
+	}
+
+	if len(object) != len(expected) {
+		t.Errorf("expected return value from validating %s with %s to be %v but it is: %v", valueDesc, validatorDesc, expected, object)
+		return
+	}
+
+	for key, expectedValue := range expected {
+		if object[key] != expectedValue {
+			t.Errorf("expected return value from validating %s with %s to be %v but it is: %v", valueDesc, validatorDesc, expected, object)
+			return
+		}
+	}
+}
+
+func TestObjectUnknownProperties(t *testing.T) {
+	value := map[string]interface{}{
+		"name": "Jane",
+		"extra": "value",
+	}
+
+	AssertObjectValidationFails(
+		t,
+		"object with unknown property",
+		"default object validator",
+		Object(Prop("name", String())),
+		value,
+	)
+	AssertObjectValidationResult(
+		t,
+		"object with unknown property",
+		"stripping object validator",
+		Object(Prop("name", String())).StripUnknown(),
+		value,
+		map[string]interface{}{"name": "Jane"},
+	)
+	AssertObjectValidationResult(
+		t,
+		"object with unknown property",
+		"pass through object validator",
+		Object(Prop("name", String())).PassThroughUnknown(),
+		value,
+		map[string]interface{}{"name": "Jane", "extra": "value"},
+	)
+	AssertObjectValidationFails(
+		t,
+		"object with unknown property",
+		"catch-all bool object validator",
+		Object(Prop("name", String())).ValidateUnknown(Bool()),
+		value,
+	)
+
+	// Test that the policy is inherited by nested objects unless overridden.
+	nestedValue := map[string]interface{}{
+		"child": map[string]interface{}{"name": "Jane", "extra": 1},
+		"children": []interface{}{map[string]interface{}{"name": "Joe", "extra": 2}},
+	}
+
+	if _, err := Object(
+		Prop("child", Object(Prop("name", String()))),
+		Prop("children", ArrayOf(Object(Prop("name", String())))),
+	).StripUnknown().Validate("", nestedValue); err != nil {
+		t.Errorf("unexpected error validating nested objects with unknown properties with stripping object validator: %v", err)
+	}
+	AssertObjectValidationFails(
+		t,
+		"nested objects with unknown properties",
+		"stripping object validator with rejecting child",
+		Object(
+			Prop("child", Object(Prop("name", String())).RejectUnknown()),
+			Prop("children", ArrayOf(Object(Prop("name", String())))),
+		).StripUnknown(),
+		nestedValue,
+	)
+
+	// Test collecting unknown properties.
+	result, err := Object(Prop("name", String())).
+		CollectUnknown("Extra").
+		UnmarshalTo(&objectTestTarget{}).
+		Validate("", value)
+	if err != nil {
+		t.Fatalf("unexpected error collecting unknown properties: %v", err)
+	}
+
+	target := result.(*objectTestTarget)
+	if target.Name != "Jane" || len(target.Extra) != 1 || target.Extra["extra"] != "value" {
+		t.Errorf("unexpected result from collecting unknown properties: %+v", target)
+	}
+
+	// Test collecting unknown properties with nested objects without a
+	// collecting field.
+	result, err = Object(
+		Prop("name", String()),
+		Prop("child", Object(Prop("name", String())).UnmarshalTo(objectTestChild{})),
+	).
+		CollectUnknown("Extra").
+		UnmarshalTo(&objectTestParent{}).
+		Validate("", map[string]interface{}{
+			"name": "Jane",
+			"child": map[string]interface{}{"name": "John", "extra": "value"},
+			"extra": "value",
+		})
+	if err != nil {
+		t.Fatalf("unexpected error collecting unknown properties of nested objects: %v", err)
+	}
+
+	parent := result.(*objectTestParent)
+	if parent.Child.Name != "John" || len(parent.Extra) != 1 || parent.Extra["extra"] != "value" {
+		t.Errorf("unexpected result from collecting unknown properties of nested objects: %+v", parent)
+	}
+}
+
+func TestObjectPatternProperties(t *testing.T) {
+	validator := Object(Prop("x-version", Int())).
+		PatternProp(`^x-`, String()).
+		PatternProp(`^feature_`, Bool())
+
+	AssertObjectValidationResult(
+		t,
+		"object with pattern properties",
+		"pattern property object validator",
+		validator,
+		map[string]interface{}{"x-version": 2.0, "x-trace": "abc", "feature_beta": true},
+		map[string]interface{}{"x-version": 2, "x-trace": "abc", "feature_beta": true},
+	)
+	AssertObjectValidationFails(
+		t,
+		"object with invalid pattern property",
+		"pattern property object validator",
+		validator,
+		map[string]interface{}{"feature_beta": "yes"},
+	)
+	AssertObjectValidationFails(
+		t,
+		"object with unmatched property",
+		"pattern property object validator",
+		validator,
+		map[string]interface{}{"other": "value"},
+	)
+}
+
+func TestObjectDefaults(t *testing.T) {
+	validator := Object(
+		Prop("name", String().Default("anonymous")),
+		Prop("settings", Object(Prop("theme", String().Default("dark"))).DefaultFromProps()),
+		Prop("items", ArrayOf(Object(Prop("qty", Int().Default(1))))),
+	)
+
+	result, defaulted, err := ValidateTrackingDefaults(validator, "", map[string]interface{}{
+		"items": []interface{}{map[string]interface{}{}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error validating object with defaults: %v", err)
+	}
+
+	obj := result.(map[string]interface{})
+	if obj["name"] != "anonymous" || obj["settings"].(map[string]interface{})["theme"] != "dark" {
+		t.Errorf("unexpected result from validating object with defaults: %v", obj)
+	}
+
+	expected := map[Path]bool{"name": true, "settings": true, "settings.theme": true, "items[0].qty": true}
+	if len(defaulted) != len(expected) {
+		t.Errorf("expected defaulted paths %v but got: %v", expected, defaulted)
+	}
+
+	for _, path := range defaulted {
+		if !expected[path] {
+			t.Errorf("unexpected defaulted path: %s", path)
+		}
+	}
+}
+
+func TestObjectRawProperties(t *testing.T) {
+	validator := Object(
+		Prop("name", String()),
+		Prop("metadata", Raw()),
+		Prop("items", ArrayOf(Object(Prop("extra", Raw())))),
+	)
+
+	body := `{"name": "Jane", "metadata": {"b": 1.50, "a": "\u00e9"}, "items": [{"extra": [1,  2]}]}`
+	req, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+
+	result, err := validator.ParseAndValidateHttpRequest(req)
+	if err != nil {
+		t.Fatalf("unexpected error validating object with raw properties: %v", err)
+	}
+
+	obj := result.(map[string]interface{})
+	if raw := obj["metadata"].(json.RawMessage); string(raw) != `{"b": 1.50, "a": "\u00e9"}` {
+		t.Errorf("expected original bytes of raw property but got: %s", raw)
+	}
+
+	item := obj["items"].([]interface{})[0].(map[string]interface{})
+	if raw := item["extra"].(json.RawMessage); string(raw) != `[1,  2]` {
+		t.Errorf("expected original bytes of nested raw property but got: %s", raw)
+	}
+}
+
+func TestObjectRawDocumentIndexing(t *testing.T) {
+	data := []byte(`{"name": "Jane", "items": [{"extra": 1}]}`)
+	var value map[string]interface{}
+	json.Unmarshal(data, &value)
+
+	// Test that the document is only indexed when a raw validator is used.
+	document := &rawJSONDocument{data: data}
+	validator := Object(Prop("name", String()), Prop("items", ArrayOf(Object(Prop("extra", Int())))))
+
+	if _, err := validateTracking(validator, "", value, &validationTracker{rawDocument: document}); err != nil {
+		t.Fatalf("unexpected error validating object without raw properties: %v", err)
+	}
+
+	if document.node != nil {
+		t.Errorf("expected document not to be indexed without raw validators")
+	}
+
+	validator = Object(Prop("name", String()), Prop("items", ArrayOf(Object(Prop("extra", Raw())))))
+
+	if _, err := validateTracking(validator, "", value, &validationTracker{rawDocument: document}); err != nil {
+		t.Fatalf("unexpected error validating object with raw properties: %v", err)
+	}
+
+	if document.node == nil {
+		t.Errorf("expected document to be indexed with raw validators")
+	}
+}
+
+func TestObjectRawPropertyPaths(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		validator *ObjectValidator
+		body string
+		expected string
+	}{
+		{
+			"object with property name containing a dot",
+			Object(Prop("a", Object(Prop("b", Raw())))).PassThroughUnknown(),
+			`{"a.b": "X", "a": {"b": 1}}`,
+			`1`,
+		},
+		{
+			"object with duplicate properties",
+			Object(Prop("m", Object(Prop("second", Raw())).PassThroughUnknown())),
+			`{"m": {"second": 1}, "m": {"second": 2}}`,
+			`2`,
+		},
+	} {
+		req, _ := http.NewRequest("POST", "/", strings.NewReader(c.body))
+
+		result, err := c.validator.ParseAndValidateHttpRequest(req)
+		if err != nil {
+			t.Errorf("unexpected error validating %s: %v", c.desc, err)
+			continue
+		}
+
+		var nested map[string]interface{}
+		for _, value := range result.(map[string]interface{}) {
+			if obj, ok := value.(map[string]interface{}); ok {
+				nested = obj
+			}
+		}
+
+		for _, value := range nested {
+			if raw := value.(json.RawMessage); string(raw) != c.expected {
+				t.Errorf("expected raw property of %s to be %s but it is: %s", c.desc, c.expected, raw)
+			}
+		}
+	}
+}
+
+func TestObjectRecursive(t *testing.T) {
+	var comment *ObjectValidator
+	comment = Object(
+		Prop("text", String().Required()),
+		Prop("replies", ArrayOf(Lazy(func() Validator {
+			return comment
+		}).MaxDepth(2))),
+	)
+
+	reply := func(replies ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"text": "reply",
+			"replies": replies,
+		}
+	}
+
+	if _, err := comment.Validate("", reply(reply(reply()))); err != nil {
+		t.Errorf("unexpected error validating recursive object: %v", err)
+	}
+
+	AssertObjectValidationFails(t, "recursive object with invalid reply", "recursive object validator", comment, reply(reply(map[string]interface{}{})))
+	AssertObjectValidationFails(t, "recursive object nested too deeply", "recursive object validator", comment, reply(reply(reply(reply()))))
+}
+
+func TestObjectComposition(t *testing.T) {
+	user := Object(
+		Prop("id", Int().Required()),
+		Prop("name", String().Required()),
+		Prop("email", String()),
+	).StripUnknown()
+
+	createUser := user.Omit("id").OverrideFunc("email", func(validator Validator) Validator {
+		return validator.(*StringValidator).Required()
+	})
+
+	AssertObjectValidationResult(
+		t,
+		"new user with unknown property",
+		"derived object validator",
+		createUser,
+		map[string]interface{}{"name": "Jane", "email": "jane@example.com", "extra": true},
+		map[string]interface{}{"name": "Jane", "email": "jane@example.com"},
+	)
+	AssertObjectValidationFails(t, "new user without email", "derived object validator", createUser, map[string]interface{}{"name": "Jane"})
+	AssertObjectValidationFails(t, "user without id", "original object validator", user, map[string]interface{}{"name": "Jane"})
+
+	AssertObjectValidationResult(
+		t,
+		"user name",
+		"picked and extended object validator",
+		user.Pick("name").Merge(Object(Prop("age", Int()))),
+		map[string]interface{}{"name": "Jane", "age": 30.0},
+		map[string]interface{}{"name": "Jane", "age": 30},
+	)
+}
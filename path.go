@@ -26,3 +26,18 @@ func (p Path) Prop(name string) Path {
 func (p Path) Elem(index int) Path {
 	return Path(fmt.Sprintf("%s[%d]", p, index))
 }
+
+// Join paths.
+//
+// Returns the path of a sub path relative to the path.
+func (p Path) Join(sub Path) Path {
+	if p == "" {
+		return sub
+	} else if sub == "" {
+		return p
+	} else if sub[0] == '[' {
+		return p + sub
+	}
+
+	return Path(string(p) + "." + string(sub))
+}
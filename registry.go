@@ -0,0 +1,107 @@
+package jsonvalid
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Registry.
+//
+// Holds validators registered by name and version, so that they can be
+// shared between validators and looked up at runtime, e.g. by the API
+// version of a request.
+type Registry struct {
+	mu sync.RWMutex
+	validators map[string]map[string]Validator
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		validators: make(map[string]map[string]Validator),
+	}
+}
+
+// Register validator.
+//
+// Panics if a validator is already registered with the name and version.
+func (r *Registry) Register(name, version string, validator Validator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versions, ok := r.validators[name]
+	if !ok {
+		versions = make(map[string]Validator)
+		r.validators[name] = versions
+	}
+
+	if _, exists := versions[version]; exists {
+		panic(fmt.Sprintf("jsonvalid: validator %s version %s is already registered", name, version))
+	}
+
+	versions[version] = validator
+}
+
+// Look up validator.
+func (r *Registry) Lookup(name, version string) (Validator, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	validator, ok := r.validators[name][version]
+	return validator, ok
+}
+
+// Versions of validator.
+//
+// Returns the sorted versions registered for a name.
+func (r *Registry) Versions(name string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := make([]string, 0, len(r.validators[name]))
+	for version := range r.validators[name] {
+		versions = append(versions, version)
+	}
+
+	sort.Strings(versions)
+	return versions
+}
+
+// Reference validator.
+//
+// Returns a validator referring to a registered validator by name and
+// version. The reference is resolved on first use, so validators may refer
+// to validators registered later, or to themselves. Validation fails with an
+// error while no validator is registered with the name and version.
+func (r *Registry) Ref(name, version string) *LazyValidator {
+	return Lazy(func() Validator {
+		if validator, ok := r.Lookup(name, version); ok {
+			return validator
+		}
+
+		return &registryRefValidator{registry: r, name: name, version: version}
+	})
+}
+
+// Registry reference validator.
+//
+// Looks up a validator which was not registered when a reference was first
+// used on every validation.
+type registryRefValidator struct {
+	registry *Registry
+	name string
+	version string
+}
+
+func (v *registryRefValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	return v.validateTracking(path, value, nil)
+}
+
+func (v *registryRefValidator) validateTracking(path Path, value interface{}, tracker *validationTracker) (interface{}, error) {
+	validator, ok := v.registry.Lookup(v.name, v.version)
+	if !ok {
+		return nil, fmt.Errorf("jsonvalid: validator %s version %s is not registered", v.name, v.version)
+	}
+
+	return validateTracking(validator, path, value, tracker)
+}
@@ -0,0 +1,73 @@
+package jsonvalid
+
+import (
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("user", "v2", Object(Prop("name", String().Required()), Prop("manager", registry.Ref("user", "v2"))))
+	registry.Register("user", "v1", Object(Prop("name", String())))
+
+	if versions := registry.Versions("user"); len(versions) != 2 || versions[0] != "v1" || versions[1] != "v2" {
+		t.Errorf("unexpected versions of registered validator: %v", versions)
+	}
+
+	if versions := registry.Versions("other"); len(versions) != 0 {
+		t.Errorf("unexpected versions of unregistered validator: %v", versions)
+	}
+
+	if _, ok := registry.Lookup("user", "v3"); ok {
+		t.Errorf("unexpected validator from looking up unregistered version")
+	}
+
+	validator, ok := registry.Lookup("user", "v2")
+	if !ok {
+		t.Fatalf("expected validator from looking up registered version")
+	}
+
+	if _, err := validator.Validate("", map[string]interface{}{
+		"name": "Jane",
+		"manager": map[string]interface{}{"name": "John"},
+	}); err != nil {
+		t.Errorf("unexpected error validating self-referencing validator: %v", err)
+	}
+
+	AssertObjectValidationFails(t, "object with invalid reference", "self-referencing validator", validator, map[string]interface{}{
+		"name": "Jane",
+		"manager": map[string]interface{}{},
+	})
+
+	// Test that registering a version twice panics.
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected panic from registering a version twice")
+			}
+		}()
+
+		registry.Register("user", "v1", Object())
+	}()
+}
+
+func TestRegistryUnregisteredRef(t *testing.T) {
+	registry := NewRegistry()
+	validator := Object(Prop("address", registry.Ref("address", "v1")))
+	value := map[string]interface{}{"address": map[string]interface{}{"city": "Copenhagen"}}
+
+	// Test that validation fails, on every use, until the validator is
+	// registered.
+	for i := 0; i < 2; i++ {
+		if _, err := validator.Validate("", value); err == nil {
+			t.Errorf("expected error from validating unregistered reference")
+		} else if _, ok := err.(*ValidationError); ok {
+			t.Errorf("expected configuration error from validating unregistered reference but got: %v", err)
+		}
+	}
+
+	registry.Register("address", "v1", Object(Prop("city", String())))
+
+	if _, err := validator.Validate("", value); err != nil {
+		t.Errorf("unexpected error validating reference registered after first use: %v", err)
+	}
+}
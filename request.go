@@ -0,0 +1,199 @@
+package jsonvalid
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// Path parameters function.
+//
+// Extracts the path parameters of a request, typically from the router.
+type PathParamsFunc func(req *http.Request) map[string]string
+
+// Request validator.
+//
+// Validates the path parameters, query parameters, headers and body of an
+// HTTP request together. The paths of validation errors are prefixed with the
+// location of the value: path, query, header or body.
+type RequestValidator struct {
+	pathParamsFunc PathParamsFunc
+	pathParams *ObjectValidator
+	query *ObjectValidator
+	headers *ObjectValidator
+	body Validator
+}
+
+func (v *RequestValidator) clone() *RequestValidator {
+	return &RequestValidator{
+		pathParamsFunc: v.pathParamsFunc,
+		pathParams: v.pathParams,
+		query: v.query,
+		headers: v.headers,
+		body: v.body,
+	}
+}
+
+// Path parameters.
+//
+// Validates the path parameters extracted by the function, so that the
+// validator does not depend on a particular router. Panics if the function is
+// nil.
+func (v *RequestValidator) PathParams(fn PathParamsFunc, validator *ObjectValidator) *RequestValidator {
+	if fn == nil {
+		panic("jsonvalid: path parameters function must not be nil")
+	}
+
+	nv := v.clone()
+	nv.pathParamsFunc = fn
+	nv.pathParams = validator
+	return nv
+}
+
+// Query parameters.
+//
+// Query parameters are parsed as with ParseAndValidateHttpQuery, so malformed
+// query strings result in ErrParseError.
+func (v *RequestValidator) Query(validator *ObjectValidator) *RequestValidator {
+	nv := v.clone()
+	nv.query = validator
+	return nv
+}
+
+// Headers.
+//
+// Only the headers declared as properties of the validator are validated.
+// Headers with multiple values are validated as arrays.
+func (v *RequestValidator) Headers(validator *ObjectValidator) *RequestValidator {
+	nv := v.clone()
+	nv.headers = validator
+	return nv
+}
+
+// JSON body.
+func (v *RequestValidator) Body(validator Validator) *RequestValidator {
+	nv := v.clone()
+	nv.body = validator
+	return nv
+}
+
+// Validated request.
+type ValidatedRequest struct {
+	PathParams interface{}
+	Query interface{}
+	Headers interface{}
+	Body interface{}
+}
+
+func (v *RequestValidator) ValidateHttpRequest(req *http.Request) (*ValidatedRequest, error) {
+	var err *ValidationError
+	var partErr error
+	result := &ValidatedRequest{}
+
+	// Validate path parameters.
+	if v.pathParams != nil {
+		params := make(map[string]interface{})
+		for name, value := range v.pathParamsFunc(req) {
+			params[name] = value
+		}
+
+		result.PathParams, partErr = inheritCoercer(v.pathParams, LenientCoercion).Validate("", params)
+		if err, partErr = collectRequestError(err, "path", partErr); partErr != nil {
+			return nil, partErr
+		}
+	}
+
+	// Validate query parameters.
+	if v.query != nil {
+		values, parseErr := url.ParseQuery(req.URL.RawQuery)
+		if parseErr != nil {
+			return nil, ErrParseError
+		}
+
+		query, parseErr := ParseFormValues(values)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+
+		result.Query, partErr = inheritCoercer(v.query, LenientCoercion).Validate("", query)
+		if err, partErr = collectRequestError(err, "query", partErr); partErr != nil {
+			return nil, partErr
+		}
+	}
+
+	// Validate headers.
+	if v.headers != nil {
+		headers := make(map[string]interface{})
+		for name := range v.headers.props {
+			values := req.Header.Values(name)
+
+			if len(values) == 1 {
+				headers[name] = values[0]
+			} else if len(values) > 1 {
+				arr := make([]interface{}, len(values))
+				for i, value := range values {
+					arr[i] = value
+				}
+				headers[name] = arr
+			}
+		}
+
+		result.Headers, partErr = inheritCoercer(v.headers, LenientCoercion).Validate("", headers)
+		if err, partErr = collectRequestError(err, "header", partErr); partErr != nil {
+			return nil, partErr
+		}
+	}
+
+	// Validate the JSON body.
+	if v.body != nil {
+		var body interface{}
+		tracker := &validationTracker{}
+
+		if req.Body != nil {
+			data, readErr := ioutil.ReadAll(req.Body)
+			if readErr != nil {
+				return nil, ErrParseError
+			}
+
+			if len(data) > 0 {
+				if readErr = decodeJSON(data, &body); readErr != nil {
+					return nil, ErrParseError
+				}
+
+				tracker.rawDocument = &rawJSONDocument{data: data}
+			}
+		}
+
+		result.Body, partErr = validateTracking(v.body, "", body, tracker)
+		if err, partErr = collectRequestError(err, "body", partErr); partErr != nil {
+			return nil, partErr
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func Request() *RequestValidator {
+	return &RequestValidator{}
+}
+
+// Collect request error.
+//
+// Accumulates a validation error of a part of a request with paths prefixed
+// by the location. Other errors are returned as is.
+func collectRequestError(err *ValidationError, location Path, partErr error) (*ValidationError, error) {
+	if partErr == nil {
+		return err, nil
+	}
+
+	validationErr, ok := partErr.(*ValidationError)
+	if !ok {
+		return err, partErr
+	}
+
+	return concatValidationError(err, prefixValidationError(location, validationErr)), nil
+}
@@ -0,0 +1,72 @@
+package jsonvalid
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func requestTestPathParams(req *http.Request) map[string]string {
+	return map[string]string{"id": strings.TrimPrefix(req.URL.Path, "/users/")}
+}
+
+func TestRequestValidator(t *testing.T) {
+	validator := Request().
+		PathParams(requestTestPathParams, Object(Prop("id", Int().Required()))).
+		Query(Object(Prop("page", Int()), Prop("tags", ArrayOf(String())))).
+		Headers(Object(Prop("X-Version", Int().Required())).StripUnknown()).
+		Body(Object(Prop("name", String().Required())))
+
+	req, _ := http.NewRequest("POST", "/users/12?page=2&tags=a", strings.NewReader(`{"name": "Jane"}`))
+	req.Header.Set("X-Version", "3")
+
+	result, err := validator.ValidateHttpRequest(req)
+	if err != nil {
+		t.Fatalf("unexpected error validating request: %v", err)
+	}
+
+	query := result.Query.(map[string]interface{})
+	if result.PathParams.(map[string]interface{})["id"] != 12 || query["page"] != 2 || len(query["tags"].([]interface{})) != 1 || result.Headers.(map[string]interface{})["X-Version"] != 3 || result.Body.(map[string]interface{})["name"] != "Jane" {
+		t.Errorf("unexpected result from validating request: %+v", result)
+	}
+
+	// Test that the paths of errors are prefixed with the location.
+	req, _ = http.NewRequest("POST", "/users/x?page=x", strings.NewReader(`{}`))
+
+	_, err = validator.ValidateHttpRequest(req)
+	AssertFieldError(t, err, "path.id", "invalid_type")
+	AssertFieldError(t, err, "query.page", "invalid_type")
+	AssertFieldError(t, err, "header.X-Version", "required")
+	AssertFieldError(t, err, "body.name", "required")
+
+	if validationErr, ok := err.(*ValidationError); !ok || len(validationErr.Fields) != 4 {
+		t.Errorf("expected 4 errors from validating invalid request but got: %v", err)
+	}
+
+	// Test that malformed query strings and bodies are rejected.
+	for _, c := range []struct {
+		desc string
+		url string
+		body string
+	}{
+		{"malformed query string", "/users/12?page=%zz", `{"name": "Jane"}`},
+		{"malformed body", "/users/12", `{"name": `},
+	} {
+		req, _ = http.NewRequest("POST", c.url, strings.NewReader(c.body))
+		req.Header.Set("X-Version", "3")
+
+		if _, err = validator.ValidateHttpRequest(req); err != ErrParseError {
+			t.Errorf("expected parse error from validating request with %s but got: %v", c.desc, err)
+		}
+	}
+}
+
+func TestRequestValidatorNilPathParams(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic from setting nil path parameters function")
+		}
+	}()
+
+	Request().PathParams(nil, Object())
+}
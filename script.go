@@ -0,0 +1,173 @@
+package jsonvalid
+
+import (
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+// Script combinations.
+//
+// Scripts which are commonly mixed in a single identifier, following the
+// highly restrictive level of Unicode Technical Standard #39.
+var allowedScriptCombinations = [][]string{
+	{"Latin", "Han", "Hiragana", "Katakana"},
+	{"Latin", "Han", "Bopomofo"},
+	{"Latin", "Han", "Hangul"},
+}
+
+// Script of rune.
+//
+// Returns the name of the script of the rune, or an empty string for common
+// and inherited characters, such as digits, punctuation and combining marks.
+func scriptOf(r rune) string {
+	if unicode.In(r, unicode.Common, unicode.Inherited) {
+		return ""
+	}
+
+	// Test the most common scripts first.
+	for _, name := range []string{"Latin", "Cyrillic", "Greek", "Han", "Arabic", "Hebrew"} {
+		if unicode.Is(unicode.Scripts[name], r) {
+			return name
+		}
+	}
+
+	for name, table := range unicode.Scripts {
+		if unicode.Is(table, r) {
+			return name
+		}
+	}
+
+	return ""
+}
+
+func scriptsOf(value string) map[string]struct{} {
+	scripts := make(map[string]struct{})
+
+	for _, r := range value {
+		if script := scriptOf(r); script != "" {
+			scripts[script] = struct{}{}
+		}
+	}
+
+	return scripts
+}
+
+func isScriptSubset(scripts map[string]struct{}, allowed []string) bool {
+	for script := range scripts {
+		found := false
+		for _, name := range allowed {
+			if script == name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Single script.
+//
+// Rejects values mixing characters of different scripts, such as Latin and
+// Cyrillic look-alikes. Common combinations, such as Latin with Han and
+// Japanese kana, are allowed.
+func (v *StringValidator) SingleScript() *StringValidator {
+	return v.ValidateValue(func(value string) (string, *ValueError) {
+		scripts := scriptsOf(value)
+		if len(scripts) <= 1 {
+			return value, nil
+		}
+
+		for _, combination := range allowedScriptCombinations {
+			if isScriptSubset(scripts, combination) {
+				return value, nil
+			}
+		}
+
+		return value, &ValueError{
+			Code: "mixed_script",
+			Message: "Value must not mix characters of different scripts",
+		}
+	})
+}
+
+// Scripts.
+//
+// Restricts values to characters of the given scripts, named as in
+// unicode.Scripts, as well as common and inherited characters.
+func (v *StringValidator) Scripts(names ...string) *StringValidator {
+	return v.ValidateValue(func(value string) (string, *ValueError) {
+		if !isScriptSubset(scriptsOf(value), names) {
+			return value, &ValueError{
+				Code: "invalid_script",
+				Message: "Value contains characters of scripts which are not allowed",
+			}
+		}
+
+		return value, nil
+	})
+}
+
+// Not confusable.
+//
+// Rejects values whose skeleton is reported as taken by the function, for
+// example by looking up the skeletons of existing user names.
+func (v *StringValidator) NotConfusable(taken func(skeleton string) bool) *StringValidator {
+	return v.ValidateValue(func(value string) (string, *ValueError) {
+		if taken(Skeleton(value)) {
+			return value, &ValueError{
+				Code: "confusable",
+				Message: "Value is confusable with an existing value",
+			}
+		}
+
+		return value, nil
+	})
+}
+
+// Confusable characters.
+//
+// Maps characters to the characters they are commonly confused with, covering
+// the Cyrillic and Greek look-alikes of Latin letters and digits.
+var confusables = map[rune]string{
+	'а': "a", 'в': "b", 'е': "e", 'ё': "e", 'һ': "h", 'і': "i", 'ј': "j",
+	'к': "k", 'м': "m", 'н': "h", 'о': "o", 'р': "p", 'с': "c", 'т': "t",
+	'у': "y", 'х': "x", 'ѕ': "s", 'ԁ': "d", 'ԛ': "q", 'ԝ': "w", 'ь': "b",
+	'А': "A", 'В': "B", 'Е': "E", 'К': "K", 'М': "M", 'Н': "H", 'О': "O",
+	'Р': "P", 'С': "C", 'Т': "T", 'Х': "X", 'Ѕ': "S", 'І': "I", 'Ј': "J",
+	'α': "a", 'ε': "e", 'ι': "i", 'κ': "k", 'ν': "v", 'ο': "o", 'ρ': "p",
+	'τ': "t", 'υ': "u", 'χ': "x", 'ω': "w",
+	'Α': "A", 'Β': "B", 'Ε': "E", 'Ζ': "Z", 'Η': "H", 'Ι': "I", 'Κ': "K",
+	'Μ': "M", 'Ν': "N", 'Ο': "O", 'Ρ': "P", 'Τ': "T", 'Υ': "Y", 'Χ': "X",
+	'0': "O", '1': "l", 'I': "l", '|': "l", 'ı': "i",
+	'ℓ': "l", 'ⅼ': "l", '℮': "e",
+}
+
+// Skeleton.
+//
+// Computes the skeleton of a string for confusable comparison, in the manner
+// of Unicode Technical Standard #39: two strings with the same skeleton are
+// visually confusable. The mapping covers common look-alikes only.
+func Skeleton(value string) string {
+	var b strings.Builder
+
+	for _, r := range norm.NFD.String(value) {
+		// Leave out default ignorable characters.
+		if unicode.Is(unicode.Other_Default_Ignorable_Code_Point, r) || unicode.Is(unicode.Cf, r) {
+			continue
+		}
+
+		if mapped, ok := confusables[r]; ok {
+			b.WriteString(mapped)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	return norm.NFD.String(b.String())
+}
@@ -0,0 +1,65 @@
+package jsonvalid
+
+import (
+	"testing"
+)
+
+func TestStringSingleScript(t *testing.T) {
+	for _, value := range []string{"paypal", "\u0440\u0430\u0443\u0440\u0430", "user_1", "tanaka\u7530\u4e2d\u3072\u3089", "kim\uae40"} {
+		if _, err := String().SingleScript().Validate("", value); err != nil {
+			t.Errorf("unexpected error validating %q with single script validator: %v", value, err)
+		}
+	}
+
+	for _, value := range []string{"\u0440\u0430\u0443\u0440\u0430l", "pay\u0440al", "\u03b1lpha"} {
+		_, err := String().SingleScript().Validate("", value)
+		AssertFieldError(t, err, "", "mixed_script")
+	}
+
+	// Test that scripts of different combinations are not mixed.
+	if _, err := String().SingleScript().Validate("", "\u3072\u3089\uae40"); err == nil {
+		t.Errorf("expected error from validating Japanese and Korean with single script validator")
+	}
+}
+
+func TestStringScripts(t *testing.T) {
+	validator := String().Scripts("Latin", "Greek")
+
+	for _, value := range []string{"alpha", "\u03b1\u03bb\u03c6\u03b1", "alpha \u03b1 1.0", "caf\u00e9"} {
+		if _, err := validator.Validate("", value); err != nil {
+			t.Errorf("unexpected error validating %q with Latin and Greek script validator: %v", value, err)
+		}
+	}
+
+	for _, value := range []string{"\u0430lpha", "\u7530\u4e2d"} {
+		_, err := validator.Validate("", value)
+		AssertFieldError(t, err, "", "invalid_script")
+	}
+}
+
+func TestStringConfusable(t *testing.T) {
+	// Test that Latin "paypal" and Cyrillic look-alikes share a skeleton.
+	if latin, cyrillic := Skeleton("paypal"), Skeleton("\u0440\u0430\u0443\u0440\u0430l"); latin != cyrillic {
+		t.Errorf("expected skeletons of Latin and Cyrillic paypal to be equal but they are: %q, %q", latin, cyrillic)
+	}
+
+	if Skeleton("paypal") == Skeleton("paypa1l") {
+		t.Errorf("unexpected equal skeletons of different values")
+	}
+
+	if Skeleton("pay\u200bpal") != Skeleton("paypal") {
+		t.Errorf("expected default ignorable characters to be left out of skeletons")
+	}
+
+	taken := map[string]bool{Skeleton("paypal"): true}
+	validator := String().NotConfusable(func(skeleton string) bool {
+		return taken[skeleton]
+	})
+
+	_, err := validator.Validate("", "\u0440\u0430\u0443\u0440\u0430l")
+	AssertFieldError(t, err, "", "confusable")
+
+	if _, err = validator.Validate("", "paypal2"); err != nil {
+		t.Errorf("unexpected error validating value which is not confusable: %v", err)
+	}
+}
@@ -1,27 +1,40 @@
 package jsonvalid
 
 import (
+	"fmt"
 	"github.com/nickbruun/goinput"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
 	"strings"
 	"regexp"
+	"unicode"
+	"unicode/utf8"
 )
 
 type StringValueValidator func(value string) (string, *ValueError)
 
 type StringValidator struct {
 	required bool
+	defaultFunc func() string
 	valueValidators []StringValueValidator
 }
 
 func (v *StringValidator) clone() *StringValidator {
 	return &StringValidator{
 		required: v.required,
+		defaultFunc: v.defaultFunc,
 		valueValidators: v.valueValidators,
 	}
 }
 
+// Validate value.
+//
+// Value validators are applied in the order they are added, so transforms
+// such as Strip and NFC affect only the validators added after them.
 func (v *StringValidator) ValidateValue(svv StringValueValidator) *StringValidator {
 	nv := v.clone()
+	nv.valueValidators = make([]StringValueValidator, 0, len(v.valueValidators) + 1)
+	nv.valueValidators = append(nv.valueValidators, v.valueValidators...)
 	nv.valueValidators = append(nv.valueValidators, svv)
 	return nv
 }
@@ -32,6 +45,22 @@ func (v *StringValidator) Required() *StringValidator {
 	return nv
 }
 
+func (v *StringValidator) Default(value string) *StringValidator {
+	return v.DefaultFunc(func() string {
+		return value
+	})
+}
+
+func (v *StringValidator) DefaultFunc(fn func() string) *StringValidator {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+func (v *StringValidator) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
 func (v *StringValidator) Strip() *StringValidator {
 	return v.ValidateValue(func(value string) (string, *ValueError) {
 		return strings.TrimSpace(value), nil
@@ -44,6 +73,133 @@ func (v *StringValidator) StripSingleLine() *StringValidator {
 	})
 }
 
+// Unicode NFC normalization.
+func (v *StringValidator) NFC() *StringValidator {
+	return v.ValidateValue(func(value string) (string, *ValueError) {
+		return norm.NFC.String(value), nil
+	})
+}
+
+// Unicode NFKC normalization.
+//
+// Also replaces compatibility characters, such as full width letters, with
+// their canonical equivalents.
+func (v *StringValidator) NFKC() *StringValidator {
+	return v.ValidateValue(func(value string) (string, *ValueError) {
+		return norm.NFKC.String(value), nil
+	})
+}
+
+// Unicode case folding.
+func (v *StringValidator) CaseFold() *StringValidator {
+	return v.ValidateValue(func(value string) (string, *ValueError) {
+		return cases.Fold().String(value), nil
+	})
+}
+
+// Reject control characters.
+//
+// Rejects control characters other than tabs and line breaks.
+func (v *StringValidator) RejectControl() *StringValidator {
+	return v.rejectRunes(func(r rune) bool {
+		return unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r'
+	})
+}
+
+// Reject zero width characters.
+func (v *StringValidator) RejectZeroWidth() *StringValidator {
+	return v.rejectRunes(func(r rune) bool {
+		switch r {
+		case '\u200b', '\u200c', '\u200d', '\u2060', '\ufeff':
+			return true
+		}
+
+		return false
+	})
+}
+
+// Reject bidirectional override characters.
+//
+// Rejects the embedding, override and isolate characters which can be used
+// to disguise the order of text.
+func (v *StringValidator) RejectBidiOverrides() *StringValidator {
+	return v.rejectRunes(func(r rune) bool {
+		return (r >= '\u202a' && r <= '\u202e') || (r >= '\u2066' && r <= '\u2069')
+	})
+}
+
+func (v *StringValidator) rejectRunes(reject func(r rune) bool) *StringValidator {
+	return v.ValidateValue(func(value string) (string, *ValueError) {
+		if strings.IndexFunc(value, reject) >= 0 {
+			return value, &ValueError{
+				Code: "invalid_characters",
+				Message: "Value contains characters which are not allowed",
+			}
+		}
+
+		return value, nil
+	})
+}
+
+// Length unit.
+type LengthUnit int
+
+const (
+	// Length in bytes of the UTF-8 encoding.
+	Bytes LengthUnit = iota
+
+	// Length in Unicode code points.
+	Runes
+
+	// Length in grapheme clusters, or user-perceived characters.
+	Graphemes
+)
+
+func (u LengthUnit) length(value string) int {
+	switch u {
+	case Bytes:
+		return len(value)
+	case Runes:
+		return utf8.RuneCountInString(value)
+	default:
+		return graphemeClusterCount(value)
+	}
+}
+
+func (u LengthUnit) unitName() string {
+	if u == Bytes {
+		return "byte(s)"
+	}
+
+	return "character(s)"
+}
+
+func (v *StringValidator) MinLen(minLen int, unit LengthUnit) *StringValidator {
+	return v.ValidateValue(func(value string) (string, *ValueError) {
+		if unit.length(value) < minLen {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be at least %d %s long", minLen, unit.unitName()),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func (v *StringValidator) MaxLen(maxLen int, unit LengthUnit) *StringValidator {
+	return v.ValidateValue(func(value string) (string, *ValueError) {
+		if unit.length(value) > maxLen {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be at most %d %s long", maxLen, unit.unitName()),
+			}
+		}
+
+		return value, nil
+	})
+}
+
 func (v *StringValidator) OneOf(values ...string) *StringValidator {
 	valueSet := make(map[string]struct{}, len(values))
 
@@ -56,6 +212,9 @@ func (v *StringValidator) OneOf(values ...string) *StringValidator {
 			return value, &ValueError{
 				Code: "invalid",
 				Message: "Invalid value",
+				Params: &ErrorParams{
+					"allowed": values,
+				},
 			}
 		}
 
@@ -88,6 +247,10 @@ func (v *StringValidator) Validate(path Path, value interface{}) (interface{}, e
 			return "", ValidationErrorAtPath(path, ValueErrorRequired)
 		}
 
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
 		return "", nil
 	}
 
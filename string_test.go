@@ -0,0 +1,78 @@
+package jsonvalid
+
+import (
+	"testing"
+)
+
+func TestStringLength(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		value string
+		bytes int
+		runes int
+		graphemes int
+	}{
+		{"ASCII string", "abc", 3, 3, 3},
+		{"decomposed accent", "e\u0301", 3, 2, 1},
+		{"emoji ZWJ sequence", "\U0001f469\u200d\U0001f469\u200d\U0001f467", 18, 5, 1},
+		{"emoji with skin tone", "\U0001f44d\U0001f3fd", 8, 2, 1},
+		{"flags", "\U0001f1f3\U0001f1f1\U0001f1e9\U0001f1ea", 16, 4, 2},
+		{"CRLF", "a\r\nb", 4, 4, 3},
+		{"Hangul jamo", "\u1100\u1161\u11a8\u1100\u1161", 15, 5, 2},
+		{"Hangul LV syllable with trailing jamo", "\uac00\u11a8", 6, 2, 1},
+		{"Hangul LVT syllable with vowel jamo", "\uac01\u1161", 6, 2, 2},
+	} {
+		for _, u := range []struct {
+			unit LengthUnit
+			name string
+			length int
+		}{
+			{Bytes, "bytes", c.bytes},
+			{Runes, "runes", c.runes},
+			{Graphemes, "graphemes", c.graphemes},
+		} {
+			if _, err := String().MinLen(u.length, u.unit).MaxLen(u.length, u.unit).Validate("", c.value); err != nil {
+				t.Errorf("unexpected error validating %s with length of %d %s: %v", c.desc, u.length, u.name, err)
+			}
+
+			if _, err := String().MinLen(u.length + 1, u.unit).Validate("", c.value); err == nil {
+				t.Errorf("expected error from validating %s with minimum length of %d %s", c.desc, u.length + 1, u.name)
+			}
+
+			if _, err := String().MaxLen(u.length - 1, u.unit).Validate("", c.value); err == nil {
+				t.Errorf("expected error from validating %s with maximum length of %d %s", c.desc, u.length - 1, u.name)
+			}
+		}
+	}
+}
+
+func TestStringNormalization(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		validator *StringValidator
+		value string
+		expected string
+	}{
+		{"stripped NFC", String().Strip().NFC(), " e\u0301 ", "\u00e9"},
+		{"NFC before strip", String().NFC().Strip(), " e\u0301 ", "\u00e9"},
+		{"stripped NFKC", String().Strip().NFKC(), "\u3000\uff21\u3000", "A"},
+		{"stripped case folding", String().Strip().CaseFold(), " Stra\u00dfe ", "strasse"},
+		{"NFKC before case folding", String().NFKC().CaseFold(), "\uff21", "a"},
+	} {
+		result, err := c.validator.Validate("", c.value)
+		if err != nil {
+			t.Errorf("unexpected error validating %s: %v", c.desc, err)
+		} else if result != c.expected {
+			t.Errorf("expected result of %s to be %q but it is: %q", c.desc, c.expected, result)
+		}
+	}
+
+	// Test that length is measured after normalization.
+	if _, err := String().Strip().NFC().MaxLen(1, Runes).Validate("", " e\u0301 "); err != nil {
+		t.Errorf("unexpected error validating length of normalized string: %v", err)
+	}
+
+	if _, err := String().MaxLen(1, Runes).NFC().Validate("", "e\u0301"); err == nil {
+		t.Errorf("expected error from validating length before normalization")
+	}
+}
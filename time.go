@@ -0,0 +1,346 @@
+package jsonvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+type TimeValueValidator func(value time.Time) (time.Time, *ValueError)
+
+// Time validator.
+//
+// Validates timestamps, by default in RFC 3339 format, returning a
+// time.Time.
+type TimeValidator struct {
+	required bool
+	defaultFunc func() time.Time
+	layouts []string
+	location *time.Location
+	epoch bool
+	coercer Coercer
+	utc bool
+	zones []int
+	now func() time.Time
+	minFromNow *time.Duration
+	maxFromNow *time.Duration
+	valueValidators []TimeValueValidator
+}
+
+func (v *TimeValidator) clone() *TimeValidator {
+	return &TimeValidator{
+		required: v.required,
+		defaultFunc: v.defaultFunc,
+		layouts: v.layouts,
+		location: v.location,
+		epoch: v.epoch,
+		coercer: v.coercer,
+		utc: v.utc,
+		zones: v.zones,
+		now: v.now,
+		minFromNow: v.minFromNow,
+		maxFromNow: v.maxFromNow,
+		valueValidators: v.valueValidators,
+	}
+}
+
+func (v *TimeValidator) Required() *TimeValidator {
+	nv := v.clone()
+	nv.required = true
+	return nv
+}
+
+func (v *TimeValidator) Default(value time.Time) *TimeValidator {
+	return v.DefaultFunc(func() time.Time {
+		return value
+	})
+}
+
+func (v *TimeValidator) DefaultFunc(fn func() time.Time) *TimeValidator {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+func (v *TimeValidator) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
+// Layouts.
+//
+// Sets the layouts accepted, in the format of time.Parse. The first layout
+// which parses the value is used.
+func (v *TimeValidator) Layouts(layouts ...string) *TimeValidator {
+	nv := v.clone()
+	nv.layouts = layouts
+	return nv
+}
+
+// In location.
+//
+// Sets the location of timestamps parsed with layouts without a time zone.
+// Defaults to UTC.
+func (v *TimeValidator) In(location *time.Location) *TimeValidator {
+	nv := v.clone()
+	nv.location = location
+	return nv
+}
+
+// Epoch.
+//
+// Accepts numbers as the number of seconds since the Unix epoch, as well as
+// strings which do not match a layout and are coerced to numbers.
+func (v *TimeValidator) Epoch() *TimeValidator {
+	nv := v.clone()
+	nv.epoch = true
+	return nv
+}
+
+// Coerce.
+//
+// Sets the coercer used instead of the default coercer.
+func (v *TimeValidator) Coerce(coercer Coercer) *TimeValidator {
+	nv := v.clone()
+	nv.coercer = coercer
+	return nv
+}
+
+func (v *TimeValidator) inheritCoercer(coercer Coercer) Validator {
+	if v.coercer != nil {
+		return v
+	}
+
+	return v.Coerce(coercer)
+}
+
+// UTC.
+//
+// Normalizes timestamps to UTC.
+func (v *TimeValidator) UTC() *TimeValidator {
+	nv := v.clone()
+	nv.utc = true
+	return nv
+}
+
+// Zones.
+//
+// Only accepts timestamps with one of the given UTC offsets, in seconds east
+// of UTC. The offset is checked as parsed, before normalizing to UTC.
+func (v *TimeValidator) Zones(offsets ...int) *TimeValidator {
+	nv := v.clone()
+	nv.zones = offsets
+	return nv
+}
+
+// Now.
+//
+// Sets the function used to determine the current time for relative bounds.
+// Defaults to time.Now.
+func (v *TimeValidator) Now(now func() time.Time) *TimeValidator {
+	nv := v.clone()
+	nv.now = now
+	return nv
+}
+
+func (v *TimeValidator) ValidateValue(tvv TimeValueValidator) *TimeValidator {
+	nv := v.clone()
+	nv.valueValidators = make([]TimeValueValidator, 0, len(v.valueValidators) + 1)
+	nv.valueValidators = append(nv.valueValidators, v.valueValidators...)
+	nv.valueValidators = append(nv.valueValidators, tvv)
+	return nv
+}
+
+func (v *TimeValidator) Min(minValue time.Time) *TimeValidator {
+	return v.ValidateValue(func(value time.Time) (time.Time, *ValueError) {
+		if value.Before(minValue) {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be at or after %s", minValue.Format(time.RFC3339)),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+func (v *TimeValidator) Max(maxValue time.Time) *TimeValidator {
+	return v.ValidateValue(func(value time.Time) (time.Time, *ValueError) {
+		if value.After(maxValue) {
+			return value, &ValueError{
+				Code: "invalid",
+				Message: fmt.Sprintf("Value must be at or before %s", maxValue.Format(time.RFC3339)),
+			}
+		}
+
+		return value, nil
+	})
+}
+
+// Minimum relative to now.
+//
+// Requires the value to be at or after the current time plus the duration,
+// which may be negative.
+func (v *TimeValidator) MinFromNow(d time.Duration) *TimeValidator {
+	nv := v.clone()
+	nv.minFromNow = &d
+	return nv
+}
+
+// Maximum relative to now.
+//
+// Requires the value to be at or before the current time plus the duration,
+// which may be negative.
+func (v *TimeValidator) MaxFromNow(d time.Duration) *TimeValidator {
+	nv := v.clone()
+	nv.maxFromNow = &d
+	return nv
+}
+
+func (v *TimeValidator) NotInPast() *TimeValidator {
+	return v.MinFromNow(0)
+}
+
+func (v *TimeValidator) NotInFuture() *TimeValidator {
+	return v.MaxFromNow(0)
+}
+
+func (v *TimeValidator) currentTime() time.Time {
+	if v.now != nil {
+		return v.now()
+	}
+
+	return time.Now()
+}
+
+func (v *TimeValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	// Test if the value is nil, in which case we can short-circuit to checking
+	// if the value is required.
+	if value == nil {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
+		return nil, nil
+	}
+
+	// Parse the value.
+	var timeValue time.Time
+	var ok bool
+
+	switch tv := value.(type) {
+	case string:
+		timeValue, ok = parseTime(tv, v.layouts, v.location)
+
+		if !ok && v.epoch {
+			timeValue, ok = epochValue(coerce(v.coercer, CoerceToNumber, tv), true)
+		}
+	default:
+		timeValue, ok = epochValue(value, v.epoch)
+	}
+
+	if !ok {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid_datetime",
+			Message: "Value must be a date and time",
+		})
+	}
+
+	// Validate the time zone before normalizing.
+	if v.zones != nil {
+		_, offset := timeValue.Zone()
+		allowed := false
+
+		for _, zone := range v.zones {
+			if offset == zone {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return nil, ValidationErrorAtPath(path, ValueError{
+				Code: "invalid",
+				Message: "Time zone is not allowed",
+			})
+		}
+	}
+
+	if v.utc {
+		timeValue = timeValue.UTC()
+	}
+
+	// Validate relative bounds.
+	if v.minFromNow != nil || v.maxFromNow != nil {
+		current := v.currentTime()
+
+		if v.minFromNow != nil && timeValue.Before(current.Add(*v.minFromNow)) {
+			return nil, ValidationErrorAtPath(path, ValueError{
+				Code: "invalid",
+				Message: "Value is too early",
+			})
+		}
+
+		if v.maxFromNow != nil && timeValue.After(current.Add(*v.maxFromNow)) {
+			return nil, ValidationErrorAtPath(path, ValueError{
+				Code: "invalid",
+				Message: "Value is too late",
+			})
+		}
+	}
+
+	// Validate the value.
+	for _, valueValidator := range v.valueValidators {
+		var err *ValueError
+		if timeValue, err = valueValidator(timeValue); err != nil {
+			return nil, ValidationErrorAtPath(path, *err)
+		}
+	}
+
+	return timeValue, nil
+}
+
+func Time() *TimeValidator {
+	return &TimeValidator{
+		layouts: []string{time.RFC3339Nano},
+	}
+}
+
+func parseTime(value string, layouts []string, location *time.Location) (time.Time, bool) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func epochValue(value interface{}, accept bool) (time.Time, bool) {
+	switch tv := value.(type) {
+	case float64:
+		return epochTime(tv, accept)
+	case json.Number:
+		floatValue, err := tv.Float64()
+		return epochTime(floatValue, accept && err == nil)
+	}
+
+	return time.Time{}, false
+}
+
+func epochTime(seconds float64, accept bool) (time.Time, bool) {
+	if !accept || math.IsNaN(seconds) || math.IsInf(seconds, 0) || math.Abs(seconds) > 1e15 {
+		return time.Time{}, false
+	}
+
+	whole, frac := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(frac * 1e9)).UTC(), true
+}
@@ -0,0 +1,122 @@
+package jsonvalid
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeZones(t *testing.T) {
+	validator := Time().UTC().Zones(0, 3600)
+
+	result, err := validator.Validate("", "2026-01-01T10:00:00+01:00")
+	if err != nil {
+		t.Fatalf("unexpected error validating time in allowed zone: %v", err)
+	}
+
+	if expected := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC); result != expected {
+		t.Errorf("expected %v from validating time in allowed zone but got: %v", expected, result)
+	}
+
+	if _, err = validator.Validate("", "2026-01-01T10:00:00+02:00"); err == nil {
+		t.Errorf("expected error from validating time in disallowed zone")
+	}
+}
+
+func TestTimeParsing(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+	validator := Time().Layouts(time.RFC3339, "2006-01-02 15:04").In(berlin)
+
+	result, err := validator.Validate("", "2026-03-01 12:30")
+	if err != nil {
+		t.Fatalf("unexpected error validating time with custom layout: %v", err)
+	}
+
+	if expected := time.Date(2026, 3, 1, 12, 30, 0, 0, berlin); !result.(time.Time).Equal(expected) {
+		t.Errorf("expected %v from validating time with custom layout but got: %v", expected, result)
+	}
+
+	if _, err = validator.Validate("", "01/03/2026"); err == nil {
+		t.Errorf("expected error from validating time not matching any layout")
+	}
+
+	// Test epoch input.
+	if _, err = Time().Validate("", 1767225600.0); err == nil {
+		t.Errorf("expected error from validating epoch number without epoch input")
+	}
+
+	for _, value := range []interface{}{1767225600.5, json.Number("1767225600.5")} {
+		result, err = Time().Epoch().Validate("", value)
+		if err != nil {
+			t.Errorf("unexpected error validating epoch %v: %v", value, err)
+		} else if expected := time.Date(2026, 1, 1, 0, 0, 0, 500000000, time.UTC); !result.(time.Time).Equal(expected) {
+			t.Errorf("expected %v from validating epoch %v but got: %v", expected, value, result)
+		}
+	}
+}
+
+func TestTimeRelativeBounds(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	validator := Time().Now(func() time.Time {
+		return now
+	}).NotInPast().MaxFromNow(90 * 24 * time.Hour)
+
+	for _, value := range []string{"2026-06-01T12:00:00Z", "2026-08-30T12:00:00Z"} {
+		if _, err := validator.Validate("", value); err != nil {
+			t.Errorf("unexpected error validating %s within relative bounds: %v", value, err)
+		}
+	}
+
+	for _, value := range []string{"2026-06-01T11:59:59Z", "2026-08-30T12:00:01Z"} {
+		if _, err := validator.Validate("", value); err == nil {
+			t.Errorf("expected error from validating %s outside relative bounds", value)
+		}
+	}
+
+	// Test dates relative to the injected current date.
+	dateValidator := Date().Now(func() time.Time {
+		return now
+	}).NotInPast().MaxFromToday(1)
+
+	result, err := dateValidator.Validate("", "2026-06-02")
+	if err != nil || result != (CivilDate{2026, time.June, 2}) {
+		t.Errorf("unexpected result from validating date within relative bounds: %v, %v", result, err)
+	}
+
+	for _, value := range []string{"2026-05-31", "2026-06-03", "2026-6-2"} {
+		if _, err := dateValidator.Validate("", value); err == nil {
+			t.Errorf("expected error from validating date %s", value)
+		}
+	}
+}
+
+func TestCivilJSON(t *testing.T) {
+	date := CivilDate{2026, time.February, 28}
+
+	data, err := json.Marshal(date)
+	if err != nil || string(data) != `"2026-02-28"` {
+		t.Fatalf("unexpected result from marshaling civil date: %s, %v", data, err)
+	}
+
+	var parsedDate CivilDate
+	if err = json.Unmarshal(data, &parsedDate); err != nil || parsedDate != date {
+		t.Errorf("unexpected result from unmarshaling civil date: %v, %v", parsedDate, err)
+	}
+
+	for _, civilTime := range []CivilTime{{9, 30, 0, 0}, {23, 59, 59, 500000000}} {
+		data, err = json.Marshal(civilTime)
+		if err != nil {
+			t.Fatalf("unexpected error marshaling civil time: %v", err)
+		}
+
+		var parsedTime CivilTime
+		if err = json.Unmarshal(data, &parsedTime); err != nil || parsedTime != civilTime {
+			t.Errorf("unexpected result from unmarshaling civil time %s: %v, %v", data, parsedTime, err)
+		}
+	}
+
+	result, err := TimeOfDay().Validate("", "09:30:00")
+	if err != nil || result != (CivilTime{9, 30, 0, 0}) {
+		t.Errorf("unexpected result from validating time of day: %v, %v", result, err)
+	}
+}
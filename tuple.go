@@ -0,0 +1,207 @@
+package jsonvalid
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Tuple validator.
+//
+// Validates fixed-position arrays, where each element has its own validator.
+type TupleValidator struct {
+	required bool
+	defaultFunc func() interface{}
+	itemValidators []Validator
+	minLen int
+	restValidator Validator
+	targetType reflect.Type
+}
+
+func (v *TupleValidator) clone() *TupleValidator {
+	return &TupleValidator{
+		required: v.required,
+		defaultFunc: v.defaultFunc,
+		itemValidators: v.itemValidators,
+		minLen: v.minLen,
+		restValidator: v.restValidator,
+		targetType: v.targetType,
+	}
+}
+
+func (v *TupleValidator) Required() *TupleValidator {
+	nv := v.clone()
+	nv.required = true
+	return nv
+}
+
+func (v *TupleValidator) Default(value interface{}) *TupleValidator {
+	return v.DefaultFunc(func() interface{} {
+		return value
+	})
+}
+
+func (v *TupleValidator) DefaultFunc(fn func() interface{}) *TupleValidator {
+	nv := v.clone()
+	nv.defaultFunc = fn
+	return nv
+}
+
+func (v *TupleValidator) hasDefault() bool {
+	return v.defaultFunc != nil
+}
+
+// Optional trailing elements.
+//
+// Adds elements after the existing ones which may be left out of the array.
+func (v *TupleValidator) Optional(validators ...Validator) *TupleValidator {
+	nv := v.clone()
+	nv.itemValidators = make([]Validator, 0, len(v.itemValidators) + len(validators))
+	nv.itemValidators = append(nv.itemValidators, v.itemValidators...)
+	nv.itemValidators = append(nv.itemValidators, validators...)
+	return nv
+}
+
+// Rest validator.
+//
+// Validates any elements beyond the declared positions. Without a rest
+// validator, such elements are rejected.
+func (v *TupleValidator) Rest(validator Validator) *TupleValidator {
+	nv := v.clone()
+	nv.restValidator = validator
+	return nv
+}
+
+// Unmarshal to type.
+//
+// The type must be a fixed-size array or a struct, in which case elements are
+// assigned to the exported fields in order.
+func (v *TupleValidator) UnmarshalTo(typ interface{}) *TupleValidator {
+	nv := v.clone()
+
+	if refTyp, ok := typ.(reflect.Type); ok {
+		nv.targetType = refTyp
+	} else {
+		nv.targetType = reflect.TypeOf(typ)
+	}
+
+	return nv
+}
+
+func (v *TupleValidator) inheritUnknownPolicy(policy *unknownPolicy) Validator {
+	nv := v.clone()
+	nv.itemValidators = make([]Validator, len(v.itemValidators))
+
+	for i, itemValidator := range v.itemValidators {
+		nv.itemValidators[i] = inheritUnknownPolicy(itemValidator, policy)
+	}
+
+	if v.restValidator != nil {
+		nv.restValidator = inheritUnknownPolicy(v.restValidator, policy)
+	}
+
+	return nv
+}
+
+func (v *TupleValidator) inheritCoercer(coercer Coercer) Validator {
+	nv := v.clone()
+	nv.itemValidators = make([]Validator, len(v.itemValidators))
+
+	for i, itemValidator := range v.itemValidators {
+		nv.itemValidators[i] = inheritCoercer(itemValidator, coercer)
+	}
+
+	if v.restValidator != nil {
+		nv.restValidator = inheritCoercer(v.restValidator, coercer)
+	}
+
+	return nv
+}
+
+func (v *TupleValidator) Validate(path Path, value interface{}) (interface{}, error) {
+	return v.validateTracking(path, value, &validationTracker{})
+}
+
+func (v *TupleValidator) validateTracking(path Path, value interface{}, tracker *validationTracker) (interface{}, error) {
+	// Test if the value is nil, in which case we can short-circuit to checking
+	// if the value is required.
+	if value == nil {
+		if v.required {
+			return nil, ValidationErrorAtPath(path, ValueErrorRequired)
+		}
+
+		if v.defaultFunc != nil {
+			return v.defaultFunc(), nil
+		}
+
+		return nil, nil
+	}
+
+	// Test if the value is an array of an acceptable length.
+	arrValue, ok := value.([]interface{})
+	if !ok {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid_type",
+			Message: "Value must be an array",
+		})
+	}
+
+	if len(arrValue) < v.minLen {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid",
+			Message: fmt.Sprintf("Value must be an array of at least %d element(s)", v.minLen),
+		})
+	}
+
+	if v.restValidator == nil && len(arrValue) > len(v.itemValidators) {
+		return nil, ValidationErrorAtPath(path, ValueError{
+			Code: "invalid",
+			Message: fmt.Sprintf("Value must be an array of at most %d element(s)", len(v.itemValidators)),
+		})
+	}
+
+	// Validate each element.
+	var err *ValidationError
+	result := make([]interface{}, 0, len(arrValue))
+
+	for i, elemValue := range arrValue {
+		itemValidator := v.restValidator
+		if i < len(v.itemValidators) {
+			itemValidator = v.itemValidators[i]
+		}
+
+		resultValue, resultErr := validateTracking(itemValidator, path.Elem(i), elemValue, tracker.Elem(i))
+
+		if resultErr != nil {
+			if resultValidationErr, ok := resultErr.(*ValidationError); ok {
+				if err == nil {
+					err = resultValidationErr
+				} else {
+					err = err.Concat(resultValidationErr)
+				}
+			} else {
+				return nil, resultErr
+			}
+		}
+
+		result = append(result, resultValue)
+	}
+
+	// Bail if there are any errors.
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal if necessary.
+	if v.targetType == nil {
+		return result, nil
+	}
+
+	return unmarshalTuple(result, v.targetType)
+}
+
+func Tuple(validators ...Validator) *TupleValidator {
+	return &TupleValidator{
+		itemValidators: validators,
+		minLen: len(validators),
+	}
+}
@@ -0,0 +1,103 @@
+package jsonvalid
+
+import (
+	"testing"
+)
+
+type tupleTestPoint struct {
+	X int
+	Y int
+	label string
+	Label string
+}
+
+func TestTupleLength(t *testing.T) {
+	validator := Tuple(Int(), String()).Optional(Bool())
+
+	for _, value := range [][]interface{}{
+		{1.0, "a"},
+		{1.0, "a", true},
+	} {
+		result, err := validator.Validate("", value)
+		if err != nil {
+			t.Errorf("unexpected error validating tuple %v: %v", value, err)
+		} else if arr := result.([]interface{}); len(arr) != len(value) || arr[0] != 1 {
+			t.Errorf("unexpected result from validating tuple %v: %v", value, arr)
+		}
+	}
+
+	for _, value := range []interface{}{
+		[]interface{}{1.0},
+		[]interface{}{1.0, "a", true, false},
+		[]interface{}{"a", 1.0},
+		[]interface{}{1.0, "a", "b"},
+		map[string]interface{}{},
+	} {
+		if _, err := validator.Validate("", value); err == nil {
+			t.Errorf("expected error from validating tuple %v", value)
+		}
+	}
+
+	// Test that the rest validator validates the remaining elements.
+	rest := validator.Rest(Int())
+
+	if result, err := rest.Validate("", []interface{}{1.0, "a", true, 2.0, 3.0}); err != nil || len(result.([]interface{})) != 5 {
+		t.Errorf("unexpected result from validating tuple with rest elements: %v, %v", result, err)
+	}
+
+	_, err := rest.Validate("", []interface{}{1.0, "a", true, 2.0, "b"})
+	AssertFieldError(t, err, "[4]", "invalid_type")
+
+	// Test that the rest validator is not used for optional elements.
+	if _, err := rest.Validate("", []interface{}{1.0, "a", 2.0}); err == nil {
+		t.Errorf("expected error from validating tuple with invalid optional element")
+	}
+}
+
+func TestTupleUnmarshaling(t *testing.T) {
+	validator := Tuple(Int(), Int()).Optional(String())
+
+	// Test unmarshaling to fixed-size arrays.
+	result, err := validator.UnmarshalTo([3]interface{}{}).Validate("", []interface{}{1.0, 2.0})
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling tuple to array: %v", err)
+	}
+
+	if arr := result.([3]interface{}); arr[0] != 1.0 || arr[1] != 2.0 || arr[2] != nil {
+		t.Errorf("unexpected result from unmarshaling tuple to array: %v", arr)
+	}
+
+	if result, err = Tuple(Int(), Int()).UnmarshalTo(&[2]int{}).Validate("", []interface{}{1.0, 2.0}); err != nil {
+		t.Errorf("unexpected error unmarshaling tuple to array pointer: %v", err)
+	} else if arr := result.(*[2]int); arr[0] != 1 || arr[1] != 2 {
+		t.Errorf("unexpected result from unmarshaling tuple to array pointer: %v", arr)
+	}
+
+	if _, err = validator.Rest(Int()).UnmarshalTo([3]int{}).Validate("", []interface{}{1.0, 2.0, "a", 3.0}); err == nil {
+		t.Errorf("expected error from unmarshaling too long tuple to array")
+	}
+
+	// Test unmarshaling to structs, skipping unexported fields.
+	result, err = validator.UnmarshalTo(&tupleTestPoint{}).Validate("", []interface{}{1.0, 2.0, "origin"})
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling tuple to struct: %v", err)
+	}
+
+	if point := result.(*tupleTestPoint); point.X != 1 || point.Y != 2 || point.Label != "origin" || point.label != "" {
+		t.Errorf("unexpected result from unmarshaling tuple to struct: %+v", point)
+	}
+
+	if result, err = validator.UnmarshalTo(tupleTestPoint{}).Validate("", []interface{}{1.0, 2.0}); err != nil {
+		t.Errorf("unexpected error unmarshaling tuple without optional element to struct: %v", err)
+	} else if point := result.(tupleTestPoint); point.X != 1 || point.Y != 2 || point.Label != "" {
+		t.Errorf("unexpected result from unmarshaling tuple without optional element to struct: %+v", point)
+	}
+
+	if _, err = validator.Rest(Int()).UnmarshalTo(tupleTestPoint{}).Validate("", []interface{}{1.0, 2.0, "a", 3.0}); err == nil {
+		t.Errorf("expected error from unmarshaling too long tuple to struct")
+	}
+
+	if _, err = validator.UnmarshalTo(0).Validate("", []interface{}{1.0, 2.0}); err == nil {
+		t.Errorf("expected error from unmarshaling tuple to integer")
+	}
+}
@@ -0,0 +1,168 @@
+package jsonvalid
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type TypedValueValidator[T any] func(value T) (T, *ValueError)
+
+// Typed validator.
+//
+// Wraps a validator, returning its results as values of type T. Untyped
+// returns a Validator for use with Prop, ArrayOf and other validators.
+type TypedValidator[T any] struct {
+	validator Validator
+	convert func(value interface{}) (T, bool)
+	valueValidators []TypedValueValidator[T]
+}
+
+func (v *TypedValidator[T]) clone() *TypedValidator[T] {
+	return &TypedValidator[T]{
+		validator: v.validator,
+		convert: v.convert,
+		valueValidators: v.valueValidators,
+	}
+}
+
+// Validate value.
+//
+// Value validators are applied to the typed result of the wrapped validator.
+func (v *TypedValidator[T]) ValidateValue(tvv TypedValueValidator[T]) *TypedValidator[T] {
+	nv := v.clone()
+	nv.valueValidators = make([]TypedValueValidator[T], 0, len(v.valueValidators) + 1)
+	nv.valueValidators = append(nv.valueValidators, v.valueValidators...)
+	nv.valueValidators = append(nv.valueValidators, tvv)
+	return nv
+}
+
+func (v *TypedValidator[T]) Validate(path Path, value interface{}) (T, error) {
+	return v.validateTracking(path, value, nil)
+}
+
+func (v *TypedValidator[T]) validateTracking(path Path, value interface{}, tracker *validationTracker) (T, error) {
+	var zero T
+
+	result, err := validateTracking(v.validator, path, value, tracker)
+	if err != nil {
+		return zero, err
+	}
+
+	typedResult, ok := v.convert(result)
+	if !ok {
+		return zero, fmt.Errorf("jsonvalid: cannot use %T as %v", result, reflect.TypeOf(&zero).Elem())
+	}
+
+	for _, tvv := range v.valueValidators {
+		var valErr *ValueError
+		if typedResult, valErr = tvv(typedResult); valErr != nil {
+			return zero, ValidationErrorAtPath(path, *valErr)
+		}
+	}
+
+	return typedResult, nil
+}
+
+// Untyped validator.
+func (v *TypedValidator[T]) Untyped() Validator {
+	return &untypedValidator[T]{typed: v}
+}
+
+// Untyped validator.
+//
+// Adapts a typed validator to the Validator interface.
+type untypedValidator[T any] struct {
+	typed *TypedValidator[T]
+}
+
+func (v *untypedValidator[T]) Validate(path Path, value interface{}) (interface{}, error) {
+	return v.validateTracking(path, value, nil)
+}
+
+func (v *untypedValidator[T]) validateTracking(path Path, value interface{}, tracker *validationTracker) (interface{}, error) {
+	result, err := v.typed.validateTracking(path, value, tracker)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (v *untypedValidator[T]) hasDefault() bool {
+	d, ok := v.typed.validator.(defaulter)
+	return ok && d.hasDefault()
+}
+
+func (v *untypedValidator[T]) inheritUnknownPolicy(policy *unknownPolicy) Validator {
+	nv := v.typed.clone()
+	nv.validator = inheritUnknownPolicy(v.typed.validator, policy)
+	return nv.Untyped()
+}
+
+func (v *untypedValidator[T]) inheritCoercer(coercer Coercer) Validator {
+	nv := v.typed.clone()
+	nv.validator = inheritCoercer(v.typed.validator, coercer)
+	return nv.Untyped()
+}
+
+func convertTyped[T any](value interface{}) (T, bool) {
+	if value == nil {
+		var zero T
+		return zero, true
+	}
+
+	typedValue, ok := value.(T)
+	return typedValue, ok
+}
+
+// Typed.
+//
+// The results of the validator must be of type T, or nil.
+func Typed[T any](validator Validator) *TypedValidator[T] {
+	return &TypedValidator[T]{
+		validator: validator,
+		convert: convertTyped[T],
+	}
+}
+
+// Typed array.
+//
+// Validates elements with the typed item validator, returning a slice of its
+// results.
+func TypedArray[T any](array *ArrayValidator, item *TypedValidator[T]) *TypedValidator[[]T] {
+	return &TypedValidator[[]T]{
+		validator: array.Of(item.Untyped()),
+		convert: func(value interface{}) ([]T, bool) {
+			switch tv := value.(type) {
+			case nil:
+				return nil, true
+			case []T:
+				return tv, true
+			case []interface{}:
+				result := make([]T, len(tv))
+
+				for i, elemValue := range tv {
+					var ok bool
+					if result[i], ok = convertTyped[T](elemValue); !ok {
+						return nil, false
+					}
+				}
+
+				return result, true
+			}
+
+			return nil, false
+		},
+	}
+}
+
+func TypedArrayOf[T any](item *TypedValidator[T]) *TypedValidator[[]T] {
+	return TypedArray(Array(), item)
+}
+
+// Validate as.
+//
+// Validates the value, returning the result as a value of type T.
+func ValidateAs[T any](validator Validator, path Path, value interface{}) (T, error) {
+	return Typed[T](validator).Validate(path, value)
+}
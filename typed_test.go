@@ -0,0 +1,38 @@
+package jsonvalid
+
+import (
+	"testing"
+)
+
+type typedTestUser struct {
+	Name string `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+func TestTyped(t *testing.T) {
+	tags := TypedArrayOf(Typed[string](String().Required()))
+	validator := Typed[typedTestUser](Object(
+		Prop("name", String().Required()),
+		Prop("tags", tags.Untyped()),
+	).UnmarshalTo(typedTestUser{}))
+
+	user, err := validator.Validate("", map[string]interface{}{
+		"name": "Jane",
+		"tags": []interface{}{"admin", "staff"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error validating typed object: %v", err)
+	}
+
+	if user.Name != "Jane" || len(user.Tags) != 2 || user.Tags[1] != "staff" {
+		t.Errorf("unexpected result from validating typed object: %v", user)
+	}
+
+	if _, err = validator.Validate("", map[string]interface{}{"name": "Jane", "tags": []interface{}{1.0}}); err == nil {
+		t.Errorf("expected error from validating typed object with invalid tag")
+	}
+
+	if _, err = ValidateAs[string](Int(), "", 1.0); err == nil {
+		t.Errorf("expected error from validating integer as string")
+	}
+}
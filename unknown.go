@@ -0,0 +1,56 @@
+package jsonvalid
+
+// Unknown property policy mode.
+//
+// Determines how an object validator treats properties that are not declared
+// with Prop.
+type UnknownPropertyPolicy int
+
+const (
+	// Reject unknown properties with an invalid_property error.
+	UnknownPropertiesReject UnknownPropertyPolicy = iota
+
+	// Silently strip unknown properties from the result.
+	UnknownPropertiesStrip
+
+	// Pass unknown properties through to the result without validation.
+	UnknownPropertiesPassThrough
+
+	// Validate unknown properties with a catch-all validator.
+	UnknownPropertiesValidate
+
+	// Collect unknown properties into a map field of the target struct.
+	UnknownPropertiesCollect
+)
+
+type unknownPolicy struct {
+	mode UnknownPropertyPolicy
+	validator Validator
+	fieldName string
+
+	// Whether the policy was inherited from an enclosing object. Inherited
+	// collect policies pass unknown properties through when the target type
+	// has no field to collect them in.
+	inherited bool
+}
+
+// Unknown property policy inheritor.
+//
+// Implemented by validators which may contain object validators, so that the
+// unknown property policy of an object applies recursively to nested objects
+// that have not set a policy of their own.
+type unknownPolicyInheritor interface {
+	inheritUnknownPolicy(policy *unknownPolicy) Validator
+}
+
+func inheritUnknownPolicy(validator Validator, policy *unknownPolicy) Validator {
+	if policy == nil {
+		return validator
+	}
+
+	if inheritor, ok := validator.(unknownPolicyInheritor); ok {
+		return inheritor.inheritUnknownPolicy(policy)
+	}
+
+	return validator
+}
@@ -3,7 +3,9 @@ package jsonvalid
 import (
 	"fmt"
 	"reflect"
+	"encoding"
 	"encoding/json"
+	"math/big"
 )
 
 func unmarshalObject(marshaled map[string]interface{}, typ reflect.Type) (interface{}, error) {
@@ -41,3 +43,162 @@ func unmarshalObject(marshaled map[string]interface{}, typ reflect.Type) (interf
 
 	return unmarshaled.Interface(), nil
 }
+
+func unmarshalObjectCollecting(marshaled, extra map[string]interface{}, fieldName string, typ reflect.Type) (interface{}, error) {
+	unmarshaled, err := unmarshalObject(marshaled, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the collecting field.
+	value := reflect.ValueOf(unmarshaled)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	} else {
+		// Make the struct addressable.
+		addressable := reflect.New(value.Type()).Elem()
+		addressable.Set(value)
+		value = addressable
+	}
+
+	field := value.FieldByName(fieldName)
+	if !field.IsValid() || !field.CanSet() || field.Kind() != reflect.Map {
+		return nil, fmt.Errorf("cannot collect unknown properties in %v: no settable map field %s", value.Type(), fieldName)
+	}
+
+	// Unmarshal the unknown properties into the field.
+	//
+	// Just as lazy as unmarshaling the object itself.
+	extraBytes, err := json.Marshal(extra)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(extraBytes, field.Addr().Interface()); err != nil {
+		return nil, err
+	}
+
+	if typ.Kind() != reflect.Ptr {
+		return value.Interface(), nil
+	}
+
+	return unmarshaled, nil
+}
+
+// Has collecting field.
+//
+// Tests if a struct type, or pointer to one, has a settable map field with
+// the given name.
+func hasCollectingField(typ reflect.Type, fieldName string) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+
+	field, ok := typ.FieldByName(fieldName)
+	return ok && field.PkgPath == "" && field.Type.Kind() == reflect.Map
+}
+
+func unmarshalTuple(marshaled []interface{}, typ reflect.Type) (interface{}, error) {
+	// Resolve the actual array or struct type.
+	unmarshalToPtr := false
+
+	if typ.Kind() == reflect.Ptr {
+		unmarshalToPtr = true
+		typ = typ.Elem()
+	}
+
+	unmarshaled := reflect.New(typ)
+
+	switch typ.Kind() {
+	case reflect.Array:
+		if len(marshaled) > typ.Len() {
+			return nil, fmt.Errorf("cannot unmarshal tuple of %d element(s) to %v", len(marshaled), typ)
+		}
+
+		marshaledBytes, err := json.Marshal(marshaled)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = json.Unmarshal(marshaledBytes, unmarshaled.Interface()); err != nil {
+			return nil, err
+		}
+
+	case reflect.Struct:
+		// Assign elements to the exported fields in order.
+		elem := unmarshaled.Elem()
+		i := 0
+
+		for f := 0; f < typ.NumField() && i < len(marshaled); f++ {
+			if typ.Field(f).PkgPath != "" {
+				continue
+			}
+
+			marshaledBytes, err := json.Marshal(marshaled[i])
+			if err != nil {
+				return nil, err
+			}
+
+			if err = json.Unmarshal(marshaledBytes, elem.Field(f).Addr().Interface()); err != nil {
+				return nil, err
+			}
+
+			i++
+		}
+
+		if i < len(marshaled) {
+			return nil, fmt.Errorf("cannot unmarshal tuple of %d element(s) to %v", len(marshaled), typ)
+		}
+
+	default:
+		return nil, fmt.Errorf("cannot unmarshal tuple to %v", typ)
+	}
+
+	// Return the unmarshaled tuple.
+	if !unmarshalToPtr {
+		return unmarshaled.Elem().Interface(), nil
+	}
+
+	return unmarshaled.Interface(), nil
+}
+
+var (
+	bigRatType = reflect.TypeOf(big.Rat{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+func unmarshalDecimal(text string, ratValue *big.Rat, typ reflect.Type) (interface{}, error) {
+	// Resolve the actual type.
+	unmarshalToPtr := false
+
+	if typ.Kind() == reflect.Ptr {
+		unmarshalToPtr = true
+		typ = typ.Elem()
+	}
+
+	unmarshaled := reflect.New(typ)
+
+	switch {
+	case typ == bigRatType:
+		unmarshaled.Elem().Set(reflect.ValueOf(ratValue).Elem())
+	case unmarshaled.Type().Implements(textUnmarshalerType):
+		if err := unmarshaled.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+			return nil, err
+		}
+	case typ.Kind() == reflect.String:
+		unmarshaled.Elem().SetString(text)
+	default:
+		return nil, fmt.Errorf("cannot unmarshal decimal to %v", typ)
+	}
+
+	// Return the unmarshaled decimal.
+	if !unmarshalToPtr {
+		return unmarshaled.Elem().Interface(), nil
+	}
+
+	return unmarshaled.Interface(), nil
+}